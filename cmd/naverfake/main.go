@@ -0,0 +1,29 @@
+// Command naverfake serves recorded Naver Finance responses for offline demos and
+// integration tests:
+//
+//	go run ./cmd/naverfake &
+//	JUGA_NAVER_BASE_URL=http://127.0.0.1:8081 juga 삼성전자
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/ericyhkim/juga/pkg/config"
+	"github.com/ericyhkim/juga/pkg/naver/naverfake"
+)
+
+func main() {
+	addr := flag.String("addr", "127.0.0.1:8081", "Address to listen on")
+	flag.Parse()
+
+	h := naverfake.NewHandler()
+	fmt.Fprintf(os.Stderr, "Serving recorded Naver responses for %s\n", strings.Join(h.Codes(), ", "))
+	fmt.Fprintf(os.Stderr, "export %s=http://%s\n", config.EnvNaverBaseURL, *addr)
+
+	log.Fatal(http.ListenAndServe(*addr, h))
+}
@@ -0,0 +1,267 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"time"
+
+	"github.com/ericyhkim/juga/internal/sys"
+	"github.com/ericyhkim/juga/internal/ui"
+	"github.com/ericyhkim/juga/pkg/config"
+	"github.com/ericyhkim/juga/pkg/models"
+	"github.com/ericyhkim/juga/pkg/service"
+
+	"github.com/spf13/cobra"
+)
+
+var alertCmd = &cobra.Command{
+	Use:     "alert",
+	Aliases: []string{"al"},
+	Short:   "Manage price alerts",
+	Long: `Alerts fire once when a stock crosses a price or moves by a given percentage.
+A fired alert re-arms only after the price moves back past a small hysteresis band,
+so a price hovering around the threshold does not fire repeatedly.
+Run 'juga alert run' to start checking.`,
+	Example: `  juga alert add 삼성전자 --above 80000
+  juga alert add :hynix --below 170000 --exec 'notify-send "$JUGA_ALERT_MESSAGE"'
+  juga alert add 카카오 --change-pct 5
+  juga alert run --interval 1m`,
+}
+
+var alertAddCmd = &cobra.Command{
+	Use:   "add <name> (--above <price> | --below <price> | --change-pct <percent>)",
+	Short: "Add a price alert",
+	Args:  cobra.ArbitraryArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		condition, threshold, set := alertConditionFlags(cmd)
+		if len(args) < 1 || set != 1 {
+			fmt.Println(ui.RenderContextualHelp(ui.ContextualHelp{
+				Usage: "juga alert add <name> --above <price> | --below <price> | --change-pct <percent>",
+				Examples: []string{
+					"juga alert add 삼성전자 --above 80000",
+					"juga alert add :sam --change-pct 5 --exec 'notify-send \"$JUGA_ALERT_MESSAGE\"'",
+				},
+				ErrorMessage: "Please provide a stock and exactly one condition.",
+			}))
+			return
+		}
+
+		deps := GetDeps(cmd)
+
+		res, ok := resolveSingle(deps, args[0])
+		if !ok {
+			return
+		}
+
+		command, _ := cmd.Flags().GetString("exec")
+		alert, err := deps.AlertService.Add(res, condition, threshold, command)
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidAlert) {
+				deps.Logger.Error("Threshold must be positive.")
+			} else {
+				deps.Logger.Error("Error adding alert: %v", err)
+			}
+			return
+		}
+
+		fmt.Printf("Added alert #%d: %s %s\n", alert.ID, alert.Name, ui.DescribeAlertCondition(*alert))
+	},
+}
+
+// alertConditionFlags returns the condition selected on the command line and how many
+// condition flags were given.
+func alertConditionFlags(cmd *cobra.Command) (models.AlertCondition, float64, int) {
+	var condition models.AlertCondition
+	var threshold float64
+	set := 0
+
+	for _, f := range []struct {
+		flag      string
+		condition models.AlertCondition
+	}{
+		{"above", models.AlertAbove},
+		{"below", models.AlertBelow},
+		{"change-pct", models.AlertChangePct},
+	} {
+		if cmd.Flags().Changed(f.flag) {
+			threshold, _ = cmd.Flags().GetFloat64(f.flag)
+			condition = f.condition
+			set++
+		}
+	}
+	return condition, threshold, set
+}
+
+var alertListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List all alerts",
+	Run: func(cmd *cobra.Command, args []string) {
+		deps := GetDeps(cmd)
+		alerts := deps.AlertService.List()
+
+		if outputFormat(cmd).IsMachine() {
+			writeRecords(cmd, alerts)
+			return
+		}
+
+		if len(alerts) == 0 {
+			fmt.Println("No alerts defined.")
+			return
+		}
+
+		presenter := ui.NewPresenter()
+		fmt.Println(ui.RenderListTable(presenter.PrepareAlerts(alerts)))
+	},
+}
+
+var alertRemoveCmd = &cobra.Command{
+	Use:     "remove <id>...",
+	Aliases: []string{"rm", "delete", "del"},
+	Short:   "Remove alerts by ID",
+	Args:    cobra.ArbitraryArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			fmt.Println(ui.RenderContextualHelp(ui.ContextualHelp{
+				Usage:        "juga alert remove <id>...",
+				Examples:     []string{"juga alert remove 3", "juga alert rm 1 2"},
+				ErrorMessage: "Please specify the alert IDs shown by 'juga alert list'.",
+			}))
+			return
+		}
+
+		deps := GetDeps(cmd)
+		for _, arg := range args {
+			id, err := strconv.Atoi(arg)
+			if err != nil {
+				deps.Logger.Error("Invalid alert ID '%s'.", arg)
+				continue
+			}
+			if err := deps.AlertService.Remove(id); err != nil {
+				if errors.Is(err, service.ErrNotFound) {
+					deps.Logger.Error("Alert #%d not found.", id)
+				} else {
+					deps.Logger.Error("Error removing alert #%d: %v", id, err)
+				}
+				continue
+			}
+			fmt.Printf("Removed alert #%d.\n", id)
+		}
+	},
+}
+
+var alertRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Check alerts until interrupted",
+	Long: `Polls quotes for every alerted stock and fires alerts whose condition was crossed.
+Each firing prints a line to stdout (unless --quiet) and runs the alert's own command,
+or the --exec command when the alert has none. Commands run through the shell with
+JUGA_ALERT_ID, JUGA_ALERT_CODE, JUGA_ALERT_NAME, JUGA_ALERT_CONDITION, JUGA_ALERT_THRESHOLD,
+JUGA_ALERT_PRICE, JUGA_ALERT_CHANGE_PCT and JUGA_ALERT_MESSAGE set.`,
+	Example: `  juga alert run
+  juga alert run --interval 1m --exec 'notify-send juga "$JUGA_ALERT_MESSAGE"'
+  juga alert run --once   # single check, e.g. from cron`,
+	Run: func(cmd *cobra.Command, args []string) {
+		deps := GetDeps(cmd)
+
+		interval, _ := cmd.Flags().GetDuration("interval")
+		if interval <= 0 {
+			interval = config.DefaultAlertInterval
+		}
+		defaultCommand, _ := cmd.Flags().GetString("exec")
+		quiet, _ := cmd.Flags().GetBool("quiet")
+		once, _ := cmd.Flags().GetBool("once")
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+		defer stop()
+
+		for {
+			// Alerts may be added or removed while the checker runs.
+			if err := deps.AlertService.Reload(); err != nil {
+				deps.Logger.Error("%v", err)
+			}
+
+			codes := deps.AlertService.Codes()
+			if len(codes) == 0 {
+				fmt.Println("No alerts defined. Add one with 'juga alert add'.")
+				return
+			}
+
+			stocks, err := deps.Quotes.FetchStocks(ctx, codes)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				deps.Logger.Error("Error fetching data: %v", err)
+			} else {
+				events, err := deps.AlertService.Check(stocks, time.Now())
+				if err != nil {
+					deps.Logger.Error("%v", err)
+				}
+				for _, ev := range events {
+					fireAlert(cmd, deps, ev, defaultCommand, quiet)
+				}
+			}
+
+			if once {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(interval):
+			}
+		}
+	},
+}
+
+func fireAlert(cmd *cobra.Command, deps *Dependencies, ev models.AlertEvent, defaultCommand string, quiet bool) {
+	message := ui.FormatAlertEvent(ev)
+	if !quiet {
+		fmt.Printf("%s %s\n", time.Now().Format("2006-01-02 15:04:05"), message)
+	}
+
+	command := ev.Alert.Command
+	if command == "" {
+		command = defaultCommand
+	}
+	if command == "" {
+		return
+	}
+
+	env := []string{
+		fmt.Sprintf("JUGA_ALERT_ID=%d", ev.Alert.ID),
+		"JUGA_ALERT_CODE=" + ev.Alert.Code,
+		"JUGA_ALERT_NAME=" + ev.Alert.Name,
+		"JUGA_ALERT_CONDITION=" + string(ev.Alert.Condition),
+		"JUGA_ALERT_THRESHOLD=" + strconv.FormatFloat(ev.Alert.Threshold, 'f', -1, 64),
+		"JUGA_ALERT_PRICE=" + strconv.FormatFloat(ev.Stock.Price, 'f', -1, 64),
+		"JUGA_ALERT_CHANGE_PCT=" + strconv.FormatFloat(ev.Stock.ChangePercent, 'f', 2, 64),
+		"JUGA_ALERT_MESSAGE=" + message,
+	}
+	if err := sys.RunShell(cmd.Context(), command, env); err != nil {
+		deps.Logger.Error("Alert #%d command failed: %v", ev.Alert.ID, err)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(alertCmd)
+	alertCmd.AddCommand(alertAddCmd)
+	alertCmd.AddCommand(alertListCmd)
+	alertCmd.AddCommand(alertRemoveCmd)
+	alertCmd.AddCommand(alertRunCmd)
+
+	alertAddCmd.Flags().Float64("above", 0, "Fire when the price rises to or above this value")
+	alertAddCmd.Flags().Float64("below", 0, "Fire when the price falls to or below this value")
+	alertAddCmd.Flags().Float64("change-pct", 0, "Fire when the day's change reaches this percentage in either direction")
+	alertAddCmd.Flags().String("exec", "", "Shell command to run when this alert fires")
+
+	alertRunCmd.Flags().Duration("interval", config.DefaultAlertInterval, "Polling interval")
+	alertRunCmd.Flags().String("exec", "", "Shell command to run for alerts without their own command")
+	alertRunCmd.Flags().BoolP("quiet", "q", false, "Do not print fired alerts to stdout")
+	alertRunCmd.Flags().Bool("once", false, "Check once and exit")
+}
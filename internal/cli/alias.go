@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/ericyhkim/juga/internal/output"
 	"github.com/ericyhkim/juga/internal/sys"
 	"github.com/ericyhkim/juga/internal/ui"
 	"github.com/ericyhkim/juga/pkg/resolver"
@@ -118,10 +119,6 @@ var aliasListCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		deps := GetDeps(cmd)
 		all := deps.AliasService.ListAliases()
-		if len(all) == 0 {
-			fmt.Println("No aliases defined.")
-			return
-		}
 
 		keys := make([]string, 0, len(all))
 		for k := range all {
@@ -129,6 +126,20 @@ var aliasListCmd = &cobra.Command{
 		}
 		sort.Strings(keys)
 
+		if outputFormat(cmd).IsMachine() {
+			records := make([]output.AliasRecord, 0, len(keys))
+			for _, k := range keys {
+				records = append(records, output.AliasRecord{Alias: k, Code: all[k]})
+			}
+			writeRecords(cmd, records)
+			return
+		}
+
+		if len(all) == 0 {
+			fmt.Println("No aliases defined.")
+			return
+		}
+
 		var items []ui.ListItem
 		for _, k := range keys {
 			items = append(items, ui.ListItem{
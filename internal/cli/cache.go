@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ericyhkim/juga/internal/output"
+	"github.com/ericyhkim/juga/internal/ui"
+
+	"github.com/spf13/cobra"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Inspect and fix the search cache",
+	Long: `The search cache remembers which stock an unprefixed name resolved to, so the
+same query skips the fuzzy search next time.
+
+Entries are checked again whenever the ticker database changes ('juga update'):
+terms whose stock was delisted or no longer matches them are dropped. A TTL can
+make entries expire after a while.`,
+	Example: `  juga cache list
+  juga cache rm 삼전
+  juga cache ttl 720h`,
+}
+
+var cacheListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List cached search terms, most recently used first",
+	Run: func(cmd *cobra.Command, args []string) {
+		deps := GetDeps(cmd)
+		entries := deps.Cache.Entries()
+
+		if outputFormat(cmd).IsMachine() {
+			records := make([]output.CacheRecord, 0, len(entries))
+			for _, e := range entries {
+				records = append(records, output.CacheRecord{CacheEntry: e, Name: deps.Resolver.NameOf(e.Code)})
+			}
+			writeRecords(cmd, records)
+			return
+		}
+
+		if len(entries) == 0 {
+			fmt.Println("The search cache is empty.")
+			return
+		}
+
+		var items []ui.ListItem
+		for _, e := range entries {
+			value := e.Code
+			if name := deps.Resolver.NameOf(e.Code); name != "" {
+				value += " " + name
+			}
+			if !e.UpdatedAt.IsZero() {
+				value += "  (" + e.UpdatedAt.Format("2006-01-02 15:04") + ")"
+			}
+			items = append(items, ui.ListItem{Key: e.Term, Value: value})
+		}
+
+		fmt.Println(ui.RenderListTable(items))
+	},
+}
+
+var cacheRemoveCmd = &cobra.Command{
+	Use:     "remove <term> [terms...]",
+	Aliases: []string{"rm", "delete"},
+	Short:   "Forget cached search terms",
+	Args:    cobra.ArbitraryArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			fmt.Println(ui.RenderContextualHelp(ui.ContextualHelp{
+				Usage: "juga cache rm <term> [terms...]",
+				Examples: []string{
+					"juga cache rm 삼전    # Search '삼전' again next time",
+				},
+				Tip:          "Run 'juga cache list' to see the cached terms.",
+				ErrorMessage: "Please provide a search term to forget.",
+			}))
+			return
+		}
+
+		deps := GetDeps(cmd)
+		for _, term := range args {
+			if deps.Cache.Remove(term) {
+				fmt.Printf("Cache entry '%s' removed.\n", term)
+			} else {
+				fmt.Printf("Cache entry '%s' not found.\n", term)
+			}
+		}
+
+		if err := deps.Cache.Save(); err != nil {
+			deps.Logger.Error("Failed to save cache: %v", err)
+		}
+	},
+}
+
+var cacheTTLCmd = &cobra.Command{
+	Use:   "ttl [duration]",
+	Short: "Show or set how long cache entries stay valid",
+	Long: `Shows the cache TTL, or sets it when a duration such as '720h' is given.
+A TTL of 0 (the default) keeps entries until the ticker database changes.`,
+	Example: `  juga cache ttl
+  juga cache ttl 720h
+  juga cache ttl 0`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		deps := GetDeps(cmd)
+
+		if len(args) == 0 {
+			if ttl := deps.Cache.TTL(); ttl > 0 {
+				fmt.Printf("Cache entries expire after %s.\n", ttl)
+			} else {
+				fmt.Println("Cache entries do not expire.")
+			}
+			return
+		}
+
+		ttl, err := time.ParseDuration(args[0])
+		if err != nil || ttl < 0 {
+			deps.Logger.Error("Invalid duration '%s'. Use a value such as 720h, or 0 to disable.", args[0])
+			return
+		}
+
+		deps.Cache.SetTTL(ttl)
+		if err := deps.Cache.Save(); err != nil {
+			deps.Logger.Error("Failed to save cache: %v", err)
+			return
+		}
+
+		if ttl > 0 {
+			fmt.Printf("Cache entries now expire after %s.\n", deps.Cache.TTL())
+		} else {
+			fmt.Println("Cache entries no longer expire.")
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheListCmd)
+	cacheCmd.AddCommand(cacheRemoveCmd)
+	cacheCmd.AddCommand(cacheTTLCmd)
+}
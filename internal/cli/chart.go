@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/ericyhkim/juga/internal/ui"
+
+	"github.com/spf13/cobra"
+)
+
+var chartCmd = &cobra.Command{
+	Use:     "chart <name>",
+	Aliases: []string{"c"},
+	Short:   "Draw a candlestick chart for a stock",
+	Long: `Draws a candlestick chart of a stock's price history sized to your terminal.
+Rising candles are red and falling candles are blue. The period high, low and last price are annotated.`,
+	Example: `  juga chart 삼성전자
+  juga chart :sam --period 1y --interval week`,
+	Args: cobra.ArbitraryArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			fmt.Println(ui.RenderContextualHelp(ui.ContextualHelp{
+				Usage: "juga chart <name> [--period 3m] [--interval day]",
+				Examples: []string{
+					"juga chart 삼성전자                  # Last 3 months of daily candles",
+					"juga chart :sam --period 1y -i week  # Weekly candles for a year",
+				},
+				ErrorMessage: "Please specify a stock.",
+			}))
+			return
+		}
+
+		height, _ := cmd.Flags().GetInt("height")
+
+		res, candles, ok := fetchHistory(cmd, args[0])
+		if !ok {
+			return
+		}
+
+		title := res.Code
+		if res.Name != "" {
+			title = fmt.Sprintf("%s (%s)", res.Name, res.Code)
+		}
+		fmt.Println(ui.StyleNameActive.Render(title))
+		fmt.Println(ui.RenderCandleChart(candles, ui.TerminalWidth(), height))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(chartCmd)
+	chartCmd.Flags().StringP("period", "p", "3m", "How far back to look (e.g. 10d, 2w, 3m, 1y)")
+	chartCmd.Flags().StringP("interval", "i", "day", "Candle interval: day, week or month")
+	chartCmd.Flags().Int("height", 15, "Chart height in rows")
+}
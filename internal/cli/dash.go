@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ericyhkim/juga/internal/ui"
+	"github.com/ericyhkim/juga/pkg/config"
+	"github.com/ericyhkim/juga/pkg/models"
+	"github.com/ericyhkim/juga/pkg/resolver"
+	"github.com/ericyhkim/juga/pkg/service"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultDashPortfolio is used when no portfolio exists yet.
+const defaultDashPortfolio = "watchlist"
+
+var dashCmd = &cobra.Command{
+	Use:     "dash [portfolio]",
+	Aliases: []string{"d", "dashboard"},
+	Short:   "Open a full-screen dashboard",
+	Long: `Opens a full-screen dashboard with the KOSPI/KOSDAQ indices, a scrollable list of a portfolio
+and a detail pane for the selected stock. Quotes refresh automatically.
+
+Keys:
+  ←/→ or tab   Switch portfolio
+  ↑/↓          Move the selection
+  s / S        Cycle the sort column / reverse the order
+  /            Search the ticker database and add a stock to the portfolio
+  r            Refresh now
+  q            Quit`,
+	Example: `  juga dash
+  juga dash tech --interval 10s`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		deps := GetDeps(cmd)
+		interval, _ := cmd.Flags().GetDuration("interval")
+
+		src := &dashboardSource{ctx: cmd.Context(), deps: deps}
+
+		selected := defaultDashPortfolio
+		if names := src.Portfolios(); len(names) > 0 {
+			selected = names[0]
+		}
+		if len(args) > 0 {
+			selected = strings.TrimPrefix(args[0], models.PrefixPortfolio)
+		}
+
+		if err := ui.RunDashboard(src, selected, interval); err != nil {
+			deps.Logger.Error("Error running dashboard: %v", err)
+		}
+
+		if cacheErr := deps.Cache.Save(); cacheErr != nil {
+			deps.Logger.Error("Failed to save cache: %v", cacheErr)
+		}
+	},
+}
+
+// dashboardSource adapts the application services to ui.DashboardSource.
+// Ambiguous names resolve to their best match since the picker cannot run inside the dashboard.
+// The dashboard fetches from background commands, so access to the (non thread-safe)
+// repositories is serialized.
+type dashboardSource struct {
+	ctx  context.Context
+	deps *Dependencies
+	mu   sync.Mutex
+}
+
+func (s *dashboardSource) Portfolios() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all := s.deps.PortfolioService.ListPortfolios()
+	names := make([]string, 0, len(all))
+	for name := range all {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (s *dashboardSource) FetchPortfolio(name string) ([]models.Stock, error) {
+	results, err := s.resolvePortfolio(name)
+	if errors.Is(err, service.ErrNotFound) {
+		// The selected portfolio is created when the first stock is added.
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	fetchRes, err := s.deps.StockService.FetchStocks(s.ctx, results, 0)
+	if err != nil {
+		return nil, err
+	}
+	return fetchRes.Stocks, nil
+}
+
+// resolvePortfolio resolves the items of a portfolio. Quotes are fetched outside the
+// lock so that slow requests do not block the other commands.
+func (s *dashboardSource) resolvePortfolio(name string) ([]resolver.ResolutionResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items, err := s.deps.PortfolioService.GetPortfolio(name)
+	if err != nil {
+		return nil, err
+	}
+	return s.deps.Resolver.ResolveAll(items), nil
+}
+
+func (s *dashboardSource) FetchIndices() ([]models.Stock, error) {
+	return s.deps.StockService.FetchIndices(s.ctx)
+}
+
+func (s *dashboardSource) SearchTickers(query string) []models.Ticker {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	results, err := s.deps.StockService.SearchTickers(query)
+	if err != nil {
+		return nil
+	}
+	return results
+}
+
+func (s *dashboardSource) AddToPortfolio(name, code string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.deps.PortfolioService.AddItem(name, code)
+	return err
+}
+
+func init() {
+	rootCmd.AddCommand(dashCmd)
+	dashCmd.Flags().Duration("interval", config.DefaultWatchInterval, "Refresh interval")
+}
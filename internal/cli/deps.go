@@ -3,10 +3,16 @@ package cli
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"slices"
+	"time"
 
 	"github.com/ericyhkim/juga/pkg/config"
+	"github.com/ericyhkim/juga/pkg/daum"
 	"github.com/ericyhkim/juga/pkg/diag"
+	"github.com/ericyhkim/juga/pkg/models"
 	"github.com/ericyhkim/juga/pkg/naver"
+	"github.com/ericyhkim/juga/pkg/quote"
 	"github.com/ericyhkim/juga/pkg/resolver"
 	"github.com/ericyhkim/juga/pkg/service"
 	"github.com/ericyhkim/juga/pkg/storage"
@@ -20,19 +26,27 @@ type Dependencies struct {
 	Logger     diag.Logger
 	Aliases    *storage.AliasRepository
 	Portfolios *storage.PortfolioRepository
+	Ledger     *storage.LedgerRepository
+	Alerts     *storage.AlertRepository
 	Cache      *storage.CacheRepository
 	Tickers    *storage.TickerRepository
 	Resolver   *resolver.Resolver
-	Client     *naver.Client
+	Quotes     *quote.Failover
+	// Now is the clock requests are built from. Replays run at the recorded time.
+	Now func() time.Time
 
 	// Services
 	AliasService     *service.AliasService
 	PortfolioService *service.PortfolioService
+	LedgerService    *service.LedgerService
+	AlertService     *service.AlertService
 	StockService     *service.StockService
 }
 
-// NewDependencies initializes all core application components.
-func NewDependencies(logger diag.Logger) (*Dependencies, error) {
+// NewDependencies initializes all core application components. A non-nil transport
+// carries all HTTP requests, e.g. to record or replay them; a transport with a Now
+// method also provides the clock.
+func NewDependencies(logger diag.Logger, transport http.RoundTripper) (*Dependencies, error) {
 	aliasPath, err := config.GetAliasesPath()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get alias path: %w", err)
@@ -51,6 +65,27 @@ func NewDependencies(logger diag.Logger) (*Dependencies, error) {
 		logger.Error("Failed to load portfolios: %v", err)
 	}
 
+	ledgerPath, err := config.GetLedgerPath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ledger path: %w", err)
+	}
+	ledgerRepo := storage.NewLedgerRepository(ledgerPath, models.LedgerSettings{
+		FeeRate: config.DefaultBrokerFeeRate,
+		TaxRate: config.DefaultTransactionTaxRate,
+	}, logger)
+	if err := ledgerRepo.Load(); err != nil {
+		logger.Error("Failed to load ledger: %v", err)
+	}
+
+	alertPath, err := config.GetAlertsPath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alerts path: %w", err)
+	}
+	alertRepo := storage.NewAlertRepository(alertPath, logger)
+	if err := alertRepo.Load(); err != nil {
+		logger.Error("Failed to load alerts: %v", err)
+	}
+
 	cachePath, err := config.GetCachePath()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get cache path: %w", err)
@@ -67,32 +102,103 @@ func NewDependencies(logger diag.Logger) (*Dependencies, error) {
 	tickerRepo := storage.NewTickerRepository(tickerPath, logger)
 
 	resSvc := resolver.NewResolver(portRepo, aliasRepo, cacheRepo, tickerRepo, logger)
-	client := naver.NewClient(logger, naver.WithTimeout(config.DefaultClientTimeout))
+	quotes, err := newQuoteProvider(logger, config.GetQuoteProvider(), transport)
+	if err != nil {
+		return nil, err
+	}
 
 	aliasService := service.NewAliasService(aliasRepo, resSvc)
 	portfolioService := service.NewPortfolioService(portRepo)
+	ledgerService := service.NewLedgerService(ledgerRepo, portfolioService, resSvc)
+	alertService := service.NewAlertService(alertRepo, resSvc, service.AlertHysteresis{
+		PriceRatio:    config.DefaultAlertPriceHysteresis,
+		PercentPoints: config.DefaultAlertPercentHysteresis,
+	})
+	now := time.Now
+	if clock, ok := transport.(interface{ Now() time.Time }); ok {
+		now = clock.Now
+	}
+
+	var scraperOpts []naver.ScraperOption
+	if transport != nil {
+		scraperOpts = append(scraperOpts, naver.WithScraperTransport(transport))
+	}
+	if baseURL := config.GetNaverBaseURL(); baseURL != "" {
+		scraperOpts = append(scraperOpts, naver.WithScraperBaseURL(baseURL))
+	}
 	stockService := service.NewStockService(
 		tickerRepo,
-		client,
+		quotes,
 		logger,
 		config.DefaultScraperTimeout,
-		config.DefaultMaxStocks,
+		scraperOpts...,
 	)
 
 	return &Dependencies{
 		Logger:           logger,
 		Aliases:          aliasRepo,
 		Portfolios:       portRepo,
+		Ledger:           ledgerRepo,
+		Alerts:           alertRepo,
 		Cache:            cacheRepo,
 		Tickers:          tickerRepo,
 		Resolver:         resSvc,
-		Client:           client,
+		Quotes:           quotes,
+		Now:              now,
 		AliasService:     aliasService,
 		PortfolioService: portfolioService,
+		LedgerService:    ledgerService,
+		AlertService:     alertService,
 		StockService:     stockService,
 	}, nil
 }
 
+// newQuoteProvider returns the quote providers with primary first, failing over to
+// the others in the order they are listed here. Only Naver is used when its hosts
+// are redirected with JUGA_NAVER_BASE_URL.
+func newQuoteProvider(logger diag.Logger, primary string, transport http.RoundTripper) (*quote.Failover, error) {
+	baseURL := config.GetNaverBaseURL()
+	naverOpts := []naver.ClientOption{
+		naver.WithTimeout(config.DefaultClientTimeout),
+		naver.WithRetries(config.DefaultClientRetries, config.DefaultClientBackoff),
+		naver.WithBatching(config.DefaultQuoteBatchSize, config.DefaultQuoteWorkers),
+		naver.WithBaseURL(baseURL),
+	}
+	daumOpts := []daum.ClientOption{
+		daum.WithTimeout(config.DefaultClientTimeout),
+		daum.WithWorkers(config.DefaultQuoteWorkers),
+	}
+	if transport != nil {
+		naverOpts = append(naverOpts, naver.WithTransport(transport))
+		daumOpts = append(daumOpts, daum.WithTransport(transport))
+	}
+
+	naverClient := naver.NewClient(logger, naverOpts...)
+
+	// With the Naver hosts redirected, e.g. to a naverfake server, falling back to
+	// the live Daum API would quietly reach the network.
+	if baseURL != "" {
+		if primary != naver.ProviderName {
+			return nil, fmt.Errorf("%s cannot be '%s' while %s is set", config.EnvQuoteProvider, primary, config.EnvNaverBaseURL)
+		}
+		return quote.NewFailover(logger, naverClient), nil
+	}
+
+	providers := []quote.Provider{
+		naverClient,
+		daum.NewClient(logger, daumOpts...),
+	}
+
+	i := slices.IndexFunc(providers, func(p quote.Provider) bool { return p.Name() == primary })
+	if i < 0 {
+		return nil, fmt.Errorf("unknown quote provider '%s' in %s (use naver or daum)", primary, config.EnvQuoteProvider)
+	}
+	first := providers[i]
+	providers = slices.Insert(slices.Delete(providers, i, i+1), 0, first)
+
+	return quote.NewFailover(logger, providers...), nil
+}
+
 // GetDeps retrieves the Dependencies from the command context.
 func GetDeps(cmd *cobra.Command) *Dependencies {
 	if deps, ok := cmd.Context().Value(depsKey{}).(*Dependencies); ok {
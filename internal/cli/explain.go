@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/ericyhkim/juga/internal/ui"
+	"github.com/ericyhkim/juga/pkg/resolver"
+)
+
+// explainCandidateLimit caps the search candidates listed per item.
+const explainCandidateLimit = 5
+
+// runExplain prints how the inputs resolve without fetching quotes or writing the cache.
+func runExplain(deps *Dependencies, args []string) {
+	explanations := deps.Resolver.Explain(args)
+	fmt.Println(ui.RenderExplanations(prepareExplanations(deps.Resolver, explanations)))
+}
+
+func prepareExplanations(res *resolver.Resolver, explanations []resolver.Explanation) []ui.ExplanationViewModel {
+	vms := make([]ui.ExplanationViewModel, 0, len(explanations))
+	for _, e := range explanations {
+		vm := ui.ExplanationViewModel{}
+		if e.Expression {
+			vm.Summary = fmt.Sprintf("%s → expression (%d items)", e.Input, len(e.Steps))
+		} else if e.Portfolio != "" {
+			vm.Summary = fmt.Sprintf("%s → portfolio '%s' (%d items)", e.Input, e.Portfolio, len(e.Steps))
+			for _, s := range e.Shadowed {
+				vm.Notes = append(vm.Notes, fmt.Sprintf("shadows %s → %s", s.Source, describeCode(res, s.Code)))
+			}
+		}
+
+		for _, s := range e.Steps {
+			vm.Steps = append(vm.Steps, prepareExplainStep(res, s))
+		}
+		vms = append(vms, vm)
+	}
+	return vms
+}
+
+func prepareExplainStep(res *resolver.Resolver, s resolver.Step) ui.ExplainStepViewModel {
+	r := s.Result
+	vm := ui.ExplainStepViewModel{
+		Input:   r.Input,
+		Source:  string(r.Source),
+		Dropped: s.DuplicateOf != "",
+	}
+
+	if r.Status == resolver.StatusSuccess {
+		vm.Result = describeCode(res, r.Code)
+	} else {
+		vm.Source = string(resolver.SourceNone)
+		vm.Result = fmt.Sprintf("not found (%v)", r.Error)
+	}
+
+	if s.CacheKey != "" {
+		vm.Notes = append(vm.Notes, fmt.Sprintf("writes cache key '%s'", s.CacheKey))
+	}
+	if r.IsAmbiguous {
+		vm.Notes = append(vm.Notes, "ambiguous: a picker is shown when running interactively")
+	}
+	for _, sh := range s.Shadowed {
+		vm.Notes = append(vm.Notes, fmt.Sprintf("shadows %s → %s", sh.Source, describeCode(res, sh.Code)))
+	}
+	if s.DuplicateOf != "" {
+		vm.Notes = append(vm.Notes, fmt.Sprintf("dropped: same stock as '%s'", s.DuplicateOf))
+	}
+
+	for i, m := range s.Matches {
+		if i == explainCandidateLimit {
+			vm.Candidates = append(vm.Candidates, ui.ListItem{
+				Key: fmt.Sprintf("...and %d more", len(s.Matches)-explainCandidateLimit),
+			})
+			break
+		}
+		vm.Candidates = append(vm.Candidates, ui.ListItem{
+			Key:   fmt.Sprintf("%d. %s (%s)", i+1, m.Ticker.Name, m.Ticker.Code),
+			Value: fmt.Sprintf("%s %d", m.Tier, m.Score),
+		})
+	}
+
+	return vm
+}
+
+// describeCode renders a code with its name when the ticker database knows it.
+func describeCode(res *resolver.Resolver, code string) string {
+	if name := res.NameOf(code); name != "" {
+		return fmt.Sprintf("%s %s", code, name)
+	}
+	return code
+}
+
+func init() {
+	rootCmd.Flags().Bool("explain", false, "Show how each input resolves without fetching quotes")
+}
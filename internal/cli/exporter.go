@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/ericyhkim/juga/internal/exporter"
+	"github.com/ericyhkim/juga/internal/ui"
+	"github.com/ericyhkim/juga/pkg/config"
+	"github.com/ericyhkim/juga/pkg/models"
+
+	"github.com/spf13/cobra"
+)
+
+var exporterCmd = &cobra.Command{
+	Use:   "exporter [names...]",
+	Short: "Expose quotes as Prometheus metrics",
+	Long: `Periodically fetches the given stocks and portfolios plus KOSPI/KOSDAQ and serves
+them on /metrics in the Prometheus text format:
+
+  juga_stock_price, juga_stock_change_percent, juga_stock_trading_value,
+  juga_stock_market_open                    labels: code, name, market
+  juga_index_value, juga_index_change_percent, juga_index_trading_value
+  juga_fetch_errors_total, juga_fetch_duration_seconds,
+  juga_fetch_last_success_timestamp_seconds labels: target`,
+	Example: `  juga exporter --portfolio @tech
+  juga exporter --portfolio tech --portfolio bio 삼성전자 --listen :9109 --interval 30s`,
+	Args: cobra.ArbitraryArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		portfolios, _ := cmd.Flags().GetStringArray("portfolio")
+
+		inputs := append([]string{}, args...)
+		for _, p := range portfolios {
+			inputs = append(inputs, models.PrefixPortfolio+strings.TrimPrefix(p, models.PrefixPortfolio))
+		}
+
+		if len(inputs) == 0 {
+			fmt.Println(ui.RenderContextualHelp(ui.ContextualHelp{
+				Usage: "juga exporter [names...] [--portfolio <name>] [--listen :9109]",
+				Examples: []string{
+					"juga exporter --portfolio @tech",
+					"juga exporter 삼성전자 SK하이닉스 --interval 30s",
+				},
+				ErrorMessage: "Please specify stocks or a portfolio to export.",
+			}))
+			return
+		}
+
+		deps := GetDeps(cmd)
+
+		listen, _ := cmd.Flags().GetString("listen")
+		interval, _ := cmd.Flags().GetDuration("interval")
+		if interval <= 0 {
+			interval = config.DefaultExporterInterval
+		}
+
+		exp := exporter.New(exporter.Config{
+			Resolver: deps.Resolver,
+			Stocks:   deps.StockService,
+			Inputs:   inputs,
+			Interval: interval,
+			Logger:   deps.Logger,
+		})
+
+		mux := http.NewServeMux()
+		mux.Handle("GET /metrics", exp)
+		httpServer := &http.Server{
+			Addr:              listen,
+			Handler:           mux,
+			ReadHeaderTimeout: 5 * time.Second,
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+		defer stop()
+
+		// The cache is saved once the last refresh is done with it.
+		refreshed := make(chan struct{})
+		go func() {
+			exp.Run(ctx)
+			close(refreshed)
+		}()
+		go func() {
+			<-ctx.Done()
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_ = httpServer.Shutdown(shutdownCtx)
+		}()
+
+		fmt.Printf("Exporting metrics on http://%s/metrics every %s (Ctrl-C to stop)\n", listen, interval)
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			deps.Logger.Error("Server error: %v", err)
+			return
+		}
+
+		<-refreshed
+		if err := deps.Cache.Save(); err != nil {
+			deps.Logger.Error("Failed to save cache: %v", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(exporterCmd)
+	exporterCmd.Flags().StringArrayP("portfolio", "p", nil, "Portfolio to export (repeatable)")
+	exporterCmd.Flags().String("listen", config.DefaultExporterAddr, "Address to serve /metrics on")
+	exporterCmd.Flags().Duration("interval", config.DefaultExporterInterval, "Refresh interval")
+}
@@ -1,30 +1,50 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/ericyhkim/juga/internal/ui"
+	"github.com/ericyhkim/juga/pkg/config"
+	"github.com/ericyhkim/juga/pkg/models"
+	"github.com/ericyhkim/juga/pkg/service"
 	"github.com/spf13/cobra"
 )
 
+const (
+	findActionAlias     = "Set an alias"
+	findActionPortfolio = "Add to a portfolio"
+	findNewPortfolio    = "+ New portfolio"
+)
+
 var findCmd = &cobra.Command{
 	Use:     "find [query]",
 	Aliases: []string{"f", "search"},
-	Short:   "Fuzzy search for stocks by name",
+	Short:   "Fuzzy search for stocks by name or code",
 	Long: `Search the master ticker list using fuzzy matching.
 Useful for finding the 6-digit code for a company when you only know its name.
+Queries starting with a digit match stock codes by prefix.
+
+Use --market and --etf to narrow the results, and --interactive to pick a result
+and save it as an alias or add it to a portfolio right away.
 
 Example:
   juga find 삼전   -> Matches '삼성전자' (005930), '삼성전기' (009150), etc.
-  juga find 카카오  -> Matches '카카오' (035720), '카카오뱅크' (323410), etc.`,
+  juga find 카카오  -> Matches '카카오' (035720), '카카오뱅크' (323410), etc.
+  juga find 0059   -> Matches codes starting with 0059, such as '삼성전자' (005930).`,
 	Args: cobra.ArbitraryArgs,
 	Run: func(cmd *cobra.Command, args []string) {
 		if len(args) == 0 {
 			fmt.Println(ui.RenderContextualHelp(ui.ContextualHelp{
 				Usage: "juga find <search_term>",
 				Examples: []string{
-					"juga find 삼전        # Matches '삼성전자', '삼성전기', etc.",
-					"juga find NAVER       # Matches 'NAVER'",
+					"juga find 삼전               # Matches '삼성전자', '삼성전기', etc.",
+					"juga find NAVER              # Matches 'NAVER'",
+					"juga find 0059               # Matches codes starting with 0059",
+					"juga find 바이오 -m kosdaq   # Only KOSDAQ listings",
+					"juga find 반도체 --etf -i    # Pick an ETF and save it",
 				},
 				ErrorMessage: "Please provide a search term.",
 			}))
@@ -35,39 +55,202 @@ Example:
 
 		deps := GetDeps(cmd)
 
+		market, _ := cmd.Flags().GetString("market")
+		etfOnly, _ := cmd.Flags().GetBool("etf")
+		limit, _ := cmd.Flags().GetInt("limit")
+		interactive, _ := cmd.Flags().GetBool("interactive")
+
+		market = strings.ToUpper(strings.TrimSpace(market))
+		if market != "" && market != "KOSPI" && market != "KOSDAQ" {
+			deps.Logger.Error("Invalid market '%s'. Use 'kospi' or 'kosdaq'.", market)
+			return
+		}
+		if limit < 0 {
+			deps.Logger.Error("--limit must not be negative.")
+			return
+		}
+		if interactive && outputFormat(cmd).IsMachine() {
+			deps.Logger.Error("--interactive cannot be combined with --output %s.", outputFormat(cmd))
+			return
+		}
+
 		results, err := deps.StockService.SearchTickers(query)
 		if err != nil {
 			deps.Logger.Error("Error searching tickers: %v", err)
 			return
 		}
+		results = filterTickers(results, market, etfOnly)
+
+		displayCount := len(results)
+		if limit > 0 && displayCount > limit {
+			displayCount = limit
+		}
+		shown := results[:displayCount]
+
+		if outputFormat(cmd).IsMachine() {
+			writeRecords(cmd, append([]models.Ticker{}, shown...))
+			return
+		}
 
 		if len(results) == 0 {
 			fmt.Printf("No matches found for '%s'.\n", query)
 			return
 		}
 
-		limit := 10
-		displayCount := limit
-		if len(results) < limit {
-			displayCount = len(results)
+		if interactive {
+			runFindInteractive(deps, shown)
+			return
 		}
 
 		var items []ui.ListItem
-		for _, t := range results[:displayCount] {
+		for _, t := range shown {
 			items = append(items, ui.ListItem{
 				Key:   t.Name,
-				Value: fmt.Sprintf("%s [%s]", t.Code, t.Market),
+				Value: fmt.Sprintf("%s [%s]", t.Code, tickerKind(t)),
 			})
 		}
 
 		fmt.Println(ui.RenderListTable(items))
 
-		if len(results) > limit {
-			fmt.Printf("...and %d more.\n", len(results)-limit)
+		if len(results) > displayCount {
+			fmt.Printf("...and %d more.\n", len(results)-displayCount)
 		}
 	},
 }
 
+// filterTickers keeps the tickers listed on market (any market when empty) and,
+// when etfOnly is set, only ETFs. The input order is preserved.
+func filterTickers(tickers []models.Ticker, market string, etfOnly bool) []models.Ticker {
+	if market == "" && !etfOnly {
+		return tickers
+	}
+
+	filtered := make([]models.Ticker, 0, len(tickers))
+	for _, t := range tickers {
+		if market != "" && !strings.EqualFold(t.Market, market) {
+			continue
+		}
+		if etfOnly && !t.ETF {
+			continue
+		}
+		filtered = append(filtered, t)
+	}
+	return filtered
+}
+
+// tickerKind is the market label shown in the find listing.
+func tickerKind(t models.Ticker) string {
+	if t.ETF {
+		return t.Market + " ETF"
+	}
+	return t.Market
+}
+
+// runFindInteractive lets the user pick one of the results and then save it as an
+// alias or add it to a portfolio.
+func runFindInteractive(deps *Dependencies, results []models.Ticker) {
+	if !ui.IsInteractive() {
+		deps.Logger.Error("--interactive requires a terminal.")
+		return
+	}
+
+	items := make([]ui.ListItem, 0, len(results))
+	for _, t := range results {
+		items = append(items, ui.ListItem{Key: t.Name, Value: t.Code})
+	}
+
+	code, err := ui.RunPicker("Select a stock:", items)
+	if err != nil {
+		return
+	}
+
+	var name string
+	for _, t := range results {
+		if t.Code == code {
+			name = t.Name
+			break
+		}
+	}
+
+	action, err := ui.RunPicker(fmt.Sprintf("What do you want to do with %s (%s)?", name, code), []ui.ListItem{
+		{Value: findActionAlias},
+		{Value: findActionPortfolio},
+	})
+	if err != nil {
+		return
+	}
+
+	switch action {
+	case findActionAlias:
+		findSetAlias(deps, code)
+	case findActionPortfolio:
+		findAddToPortfolio(deps, code, name)
+	}
+}
+
+func findSetAlias(deps *Dependencies, code string) {
+	nick, err := ui.RunInput("Alias name:", "e.g. sam")
+	if err != nil {
+		return
+	}
+
+	res, err := deps.AliasService.SetAlias(nick, code)
+	if err != nil {
+		if errors.Is(err, service.ErrReservedName) {
+			deps.Logger.Error("Error: '%s' is a valid stock code and cannot be used as an alias.", nick)
+		} else {
+			deps.Logger.Error("Error saving alias: %v", err)
+		}
+		return
+	}
+
+	fmt.Printf("Alias set: %s -> %s (%s)\n", res.Nickname, res.Code, res.Name)
+}
+
+func findAddToPortfolio(deps *Dependencies, code, name string) {
+	all := deps.PortfolioService.ListPortfolios()
+	names := make([]string, 0, len(all))
+	for n := range all {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	options := make([]ui.ListItem, 0, len(names)+1)
+	for _, n := range names {
+		options = append(options, ui.ListItem{Value: n})
+	}
+	options = append(options, ui.ListItem{Value: findNewPortfolio})
+
+	portfolio, err := ui.RunPicker("Add to which portfolio?", options)
+	if err != nil {
+		return
+	}
+
+	if portfolio == findNewPortfolio {
+		portfolio, err = ui.RunInput("Portfolio name:", "e.g. tech")
+		if err != nil {
+			return
+		}
+		portfolio = strings.TrimPrefix(portfolio, models.PrefixPortfolio)
+	}
+
+	res, err := deps.PortfolioService.AddItem(portfolio, code)
+	if err != nil {
+		if errors.Is(err, service.ErrDuplicateItem) {
+			deps.Logger.Error("%s is already in portfolio '%s'.", name, portfolio)
+		} else {
+			deps.Logger.Error("Error updating portfolio: %v", err)
+		}
+		return
+	}
+
+	fmt.Printf("Added %s (%s) to portfolio '%s'. Now has %d items.\n", name, code, res.Name, res.Count)
+}
+
 func init() {
 	rootCmd.AddCommand(findCmd)
+	findCmd.Flags().StringP("market", "m", "", "Only show listings on a market (kospi, kosdaq)")
+	findCmd.Flags().Bool("etf", false, "Only show ETFs")
+	findCmd.Flags().IntP("limit", "n", config.DefaultFindLimit, "Maximum number of results to show (0 for all)")
+	findCmd.Flags().BoolP("interactive", "i", false, "Pick a result and save it as an alias or add it to a portfolio")
 }
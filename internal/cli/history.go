@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ericyhkim/juga/internal/ui"
+	"github.com/ericyhkim/juga/pkg/models"
+	"github.com/ericyhkim/juga/pkg/resolver"
+
+	"github.com/spf13/cobra"
+)
+
+var historyCmd = &cobra.Command{
+	Use:     "history <name>",
+	Aliases: []string{"h", "hist"},
+	Short:   "Show historical OHLCV prices for a stock",
+	Long: `Fetches daily, weekly or monthly candles (open/high/low/close/volume) for a single stock.
+The stock can be a name, code, alias, or any prefixed input accepted by the root command.`,
+	Example: `  juga history 삼성전자
+  juga history :sam --period 6m --interval week
+  juga history #005930 --period 3y --interval month`,
+	Args: cobra.ArbitraryArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			fmt.Println(ui.RenderContextualHelp(ui.ContextualHelp{
+				Usage: "juga history <name> [--period 1m] [--interval day]",
+				Examples: []string{
+					"juga history 삼성전자                 # Last month of daily candles",
+					"juga history :sam --period 6m -i week # Weekly candles for half a year",
+				},
+				ErrorMessage: "Please specify a stock.",
+			}))
+			return
+		}
+
+		res, candles, ok := fetchHistory(cmd, args[0])
+		if !ok {
+			return
+		}
+
+		title := res.Code
+		if res.Name != "" {
+			title = fmt.Sprintf("%s (%s)", res.Name, res.Code)
+		}
+		fmt.Println(ui.StyleNameActive.Render(title))
+
+		presenter := ui.NewPresenter()
+		fmt.Println(ui.RenderCandleTable(presenter.PrepareCandles(candles)))
+	},
+}
+
+// fetchHistory resolves input and fetches its candles using the command's --period and --interval flags.
+// Errors are reported through the logger, in which case ok is false.
+func fetchHistory(cmd *cobra.Command, input string) (res resolver.ResolutionResult, candles []models.Candle, ok bool) {
+	deps := GetDeps(cmd)
+
+	periodFlag, _ := cmd.Flags().GetString("period")
+	intervalFlag, _ := cmd.Flags().GetString("interval")
+
+	interval, valid := models.ParseCandleInterval(intervalFlag)
+	if !valid {
+		deps.Logger.Error("Invalid interval '%s'. Use day, week or month.", intervalFlag)
+		return res, nil, false
+	}
+
+	to := deps.Now()
+	from, err := parsePeriod(periodFlag, to)
+	if err != nil {
+		deps.Logger.Error("%v", err)
+		return res, nil, false
+	}
+
+	res, found := resolveSingle(deps, input)
+	if !found {
+		return res, nil, false
+	}
+
+	candles, err = deps.StockService.FetchCandles(cmd.Context(), res.Code, interval, from, to)
+	if err != nil {
+		deps.Logger.Error("Error fetching price history: %v", err)
+		return res, nil, false
+	}
+
+	return res, candles, true
+}
+
+// resolveSingle resolves one input to a stock, asking the user to pick when ambiguous.
+// It reports a warning and returns false when nothing matched.
+func resolveSingle(deps *Dependencies, input string) (resolver.ResolutionResult, bool) {
+	res := pickCandidate(deps, deps.Resolver.Resolve(input))
+
+	if cacheErr := deps.Cache.Save(); cacheErr != nil {
+		deps.Logger.Error("Failed to save cache: %v", cacheErr)
+	}
+
+	if res.Status != resolver.StatusSuccess {
+		fmt.Printf("⚠️  Could not find stock for '%s'\n", input)
+		return res, false
+	}
+	return res, true
+}
+
+// parsePeriod converts a relative period such as "10d", "2w", "3m" or "1y"
+// into the start time counted back from now.
+func parsePeriod(period string, now time.Time) (time.Time, error) {
+	period = strings.TrimSpace(strings.ToLower(period))
+	if len(period) < 2 {
+		return time.Time{}, fmt.Errorf("invalid period '%s' (examples: 10d, 2w, 3m, 1y)", period)
+	}
+
+	n, err := strconv.Atoi(period[:len(period)-1])
+	if err != nil || n <= 0 {
+		return time.Time{}, fmt.Errorf("invalid period '%s' (examples: 10d, 2w, 3m, 1y)", period)
+	}
+
+	switch period[len(period)-1] {
+	case 'd':
+		return now.AddDate(0, 0, -n), nil
+	case 'w':
+		return now.AddDate(0, 0, -7*n), nil
+	case 'm':
+		return now.AddDate(0, -n, 0), nil
+	case 'y':
+		return now.AddDate(-n, 0, 0), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid period '%s' (examples: 10d, 2w, 3m, 1y)", period)
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+	historyCmd.Flags().StringP("period", "p", "1m", "How far back to look (e.g. 10d, 2w, 3m, 1y)")
+	historyCmd.Flags().StringP("interval", "i", "day", "Candle interval: day, week or month")
+}
@@ -15,12 +15,22 @@ var marketCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		deps := GetDeps(cmd)
 
-		indices, err := deps.Client.FetchIndices()
+		indices, err := deps.StockService.FetchIndices(cmd.Context())
 		if err != nil {
 			deps.Logger.Error("Error fetching market data: %v", err)
 			return
 		}
 
+		if outputFormat(cmd).IsMachine() {
+			writeRecords(cmd, indices)
+			return
+		}
+
+		if tmpl := stockTemplate(cmd); tmpl != nil {
+			writeTemplate(cmd, tmpl, indices)
+			return
+		}
+
 		presenter := ui.NewPresenter()
 		marketVMs := presenter.PrepareList(indices)
 
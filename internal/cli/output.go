@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"text/template"
+
+	"github.com/ericyhkim/juga/internal/output"
+	"github.com/ericyhkim/juga/internal/ui"
+	"github.com/ericyhkim/juga/pkg/models"
+
+	"github.com/spf13/cobra"
+)
+
+// outputFormat returns the validated value of the global --output flag.
+func outputFormat(cmd *cobra.Command) output.Format {
+	value, _ := cmd.Flags().GetString("output")
+	format, err := output.ParseFormat(value)
+	if err != nil {
+		return output.FormatText
+	}
+	return format
+}
+
+// writeRecords prints records to stdout in the requested machine-readable format.
+func writeRecords(cmd *cobra.Command, records interface{}) {
+	if err := output.Write(os.Stdout, outputFormat(cmd), records); err != nil {
+		GetDeps(cmd).Logger.Error("Error writing output: %v", err)
+	}
+}
+
+// validateOutputFlags rejects unknown --output formats, broken --format templates
+// and combinations of the two.
+func validateOutputFlags(cmd *cobra.Command) error {
+	value, _ := cmd.Flags().GetString("output")
+	format, err := output.ParseFormat(value)
+	if err != nil {
+		return err
+	}
+
+	text, _ := cmd.Flags().GetString("format")
+	if text == "" {
+		return nil
+	}
+	if format.IsMachine() {
+		return fmt.Errorf("--format cannot be combined with --output %s", format)
+	}
+	_, err = ui.ParseStockTemplate(text)
+	return err
+}
+
+// stockTemplate returns the compiled --format template, or nil when none was given.
+func stockTemplate(cmd *cobra.Command) *template.Template {
+	text, _ := cmd.Flags().GetString("format")
+	if text == "" {
+		return nil
+	}
+	tmpl, err := ui.ParseStockTemplate(text)
+	if err != nil {
+		return nil
+	}
+	return tmpl
+}
+
+// writeTemplate prints each stock through the user's --format template.
+func writeTemplate(cmd *cobra.Command, tmpl *template.Template, stocks []models.Stock) {
+	if err := ui.RenderStockTemplate(os.Stdout, tmpl, stocks); err != nil {
+		GetDeps(cmd).Logger.Error("Error rendering format: %v", err)
+	}
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringP("output", "o", string(output.FormatText), "Output format: text, json, csv or tsv")
+	rootCmd.PersistentFlags().String("format", "", "Go template applied to each stock, e.g. '{{.Name}} {{formatNumber .Price}}'")
+}
@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/ericyhkim/juga/internal/output"
 	"github.com/ericyhkim/juga/internal/sys"
 	"github.com/ericyhkim/juga/internal/ui"
 	"github.com/ericyhkim/juga/pkg/service"
@@ -19,8 +20,10 @@ var portfolioCmd = &cobra.Command{
 	Short:   "Manage stock portfolios (groups)",
 	Long: `Portfolios allow you to group multiple stocks under a single name.
 When you run 'juga <portfolio_name>', it expands into all stocks in that group.
-Items in a portfolio can be aliases, stock codes, or company names.`,
+Items in a portfolio can be aliases, stock codes, or company names.
+An item can also pull in another portfolio with '@name'; cycles are detected.`,
 	Example: `  juga portfolio set my-tech 삼전 카카오 035420
+  juga portfolio set all @my-tech @bio
   juga portfolio edit my-tech
   juga my-tech`,
 }
@@ -53,7 +56,11 @@ The stocks can be provided as names, codes, or existing aliases.`,
 
 		res, err := deps.PortfolioService.CreatePortfolio(name, items)
 		if err != nil {
-			deps.Logger.Error("Error saving portfolio: %v", err)
+			if errors.Is(err, service.ErrLedgerManaged) {
+				fmt.Printf("Positions of '%s' are derived from its transactions. Record them with 'juga tx' instead.\n", name)
+			} else {
+				deps.Logger.Error("Error saving portfolio: %v", err)
+			}
 			return
 		}
 
@@ -66,7 +73,8 @@ var portEditCmd = &cobra.Command{
 	Aliases: []string{"e"},
 	Short:   "Edit a portfolio in your text editor",
 	Long: `Opens the portfolio list in your default editor ($EDITOR or nano/vi).
-Add or remove stocks line by line. Lines starting with # are ignored.`,
+Add or remove stocks line by line. Lines starting with # are ignored.
+Positions of portfolios with a transaction ledger ('juga tx') cannot be edited.`,
 	Example: `  juga portfolio edit my-tech`,
 	Args:    cobra.ArbitraryArgs,
 	Run: func(cmd *cobra.Command, args []string) {
@@ -86,7 +94,7 @@ Add or remove stocks line by line. Lines starting with # are ignored.`,
 
 		deps := GetDeps(cmd)
 
-		items, err := deps.PortfolioService.GetPortfolio(name)
+		holdings, err := deps.PortfolioService.GetHoldings(name)
 		if err != nil {
 			if errors.Is(err, service.ErrNotFound) {
 				fmt.Printf("Portfolio '%s' does not exist.\n", name)
@@ -99,10 +107,10 @@ Add or remove stocks line by line. Lines starting with # are ignored.`,
 		var sb strings.Builder
 		sb.WriteString(fmt.Sprintf("# Editing portfolio: %s\n", name))
 		sb.WriteString("# Add one stock per line (name, code, or alias).\n")
+		sb.WriteString("# To record a position: <stock> | <quantity> | <avg_price>\n")
+		sb.WriteString("# Example: 삼성전자 | 10 | 71500\n")
 		sb.WriteString("# Lines starting with # are ignored.\n")
-		for _, item := range items {
-			sb.WriteString(item + "\n")
-		}
+		sb.WriteString(deps.PortfolioService.FormatForEditing(holdings))
 
 		newContent, err := sys.OpenEditor(sb.String())
 		if err != nil {
@@ -112,7 +120,11 @@ Add or remove stocks line by line. Lines starting with # are ignored.`,
 
 		res, err := deps.PortfolioService.ParseAndSave(name, newContent)
 		if err != nil {
-			deps.Logger.Error("Error saving portfolio: %v", err)
+			if errors.Is(err, service.ErrLedgerManaged) {
+				fmt.Printf("Positions of '%s' are derived from its transactions. Record them with 'juga tx' instead.\n", name)
+			} else {
+				deps.Logger.Error("Error saving portfolio: %v", err)
+			}
 			return
 		}
 
@@ -120,6 +132,64 @@ Add or remove stocks line by line. Lines starting with # are ignored.`,
 	},
 }
 
+var portHoldCmd = &cobra.Command{
+	Use:     "hold <name> <stock> <quantity> <avg_price>",
+	Aliases: []string{"position", "pos"},
+	Short:   "Record the quantity and average buy price of a stock",
+	Long: `Records a position for a stock in a portfolio. The stock is added to the portfolio if it is not there yet,
+and the portfolio is created if it does not exist.
+Running 'juga @<name>' then shows market value and unrealized P&L for each position.
+A quantity of 0 turns the entry back into a watch-only item.
+Positions of portfolios with a transaction ledger ('juga tx') are derived from their transactions
+and can only be changed by recording new ones.`,
+	Example: `  juga portfolio hold tech 삼성전자 10 71500
+  juga portfolio hold tech :sam 0 0`,
+	Args: cobra.ArbitraryArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) < 4 {
+			fmt.Println(ui.RenderContextualHelp(ui.ContextualHelp{
+				Usage: "juga portfolio hold <name> <stock> <quantity> <avg_price>",
+				Examples: []string{
+					"juga portfolio hold tech 삼성전자 10 71500  # 10 shares bought at 71,500",
+					"juga portfolio hold tech 삼성전자 0 0       # Back to watch-only",
+				},
+				ErrorMessage: "Please provide a portfolio, a stock, a quantity and an average price.",
+			}))
+			return
+		}
+
+		name, item := args[0], args[1]
+
+		deps := GetDeps(cmd)
+
+		quantity, err := service.ParseAmount(args[2])
+		if err != nil {
+			deps.Logger.Error("Invalid quantity '%s'.", args[2])
+			return
+		}
+		avgPrice, err := service.ParseAmount(args[3])
+		if err != nil {
+			deps.Logger.Error("Invalid average price '%s'.", args[3])
+			return
+		}
+
+		if _, err := deps.PortfolioService.SetPosition(name, item, quantity, avgPrice); err != nil {
+			if errors.Is(err, service.ErrLedgerManaged) {
+				fmt.Printf("Positions of '%s' are derived from its transactions. Record them with 'juga tx' instead.\n", name)
+			} else {
+				deps.Logger.Error("Error saving position: %v", err)
+			}
+			return
+		}
+
+		if quantity == 0 {
+			fmt.Printf("Position for '%s' in '%s' cleared.\n", item, name)
+			return
+		}
+		fmt.Printf("Position saved: %s -> %g @ %s in '%s'.\n", item, quantity, args[3], name)
+	},
+}
+
 var portRemoveCmd = &cobra.Command{
 	Use:     "remove <name>",
 	Aliases: []string{"rm"},
@@ -162,10 +232,6 @@ var portListCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		deps := GetDeps(cmd)
 		all := deps.PortfolioService.ListPortfolios()
-		if len(all) == 0 {
-			fmt.Println("No portfolios defined.")
-			return
-		}
 
 		keys := make([]string, 0, len(all))
 		for k := range all {
@@ -173,11 +239,35 @@ var portListCmd = &cobra.Command{
 		}
 		sort.Strings(keys)
 
+		if outputFormat(cmd).IsMachine() {
+			records := make([]output.PortfolioRecord, 0, len(keys))
+			for _, k := range keys {
+				for _, h := range all[k] {
+					records = append(records, output.PortfolioRecord{Portfolio: k, Holding: h})
+				}
+			}
+			writeRecords(cmd, records)
+			return
+		}
+
+		if len(all) == 0 {
+			fmt.Println("No portfolios defined.")
+			return
+		}
+
 		var items []ui.ListItem
 		for _, k := range keys {
+			entries := make([]string, 0, len(all[k]))
+			for _, h := range all[k] {
+				if h.HasPosition() {
+					entries = append(entries, fmt.Sprintf("%s (%g)", h.Item, h.Quantity))
+				} else {
+					entries = append(entries, h.Item)
+				}
+			}
 			items = append(items, ui.ListItem{
 				Key:   k,
-				Value: strings.Join(all[k], ", "),
+				Value: strings.Join(entries, ", "),
 			})
 		}
 
@@ -189,6 +279,7 @@ func init() {
 	rootCmd.AddCommand(portfolioCmd)
 	portfolioCmd.AddCommand(portSetCmd)
 	portfolioCmd.AddCommand(portEditCmd)
+	portfolioCmd.AddCommand(portHoldCmd)
 	portfolioCmd.AddCommand(portRemoveCmd)
 	portfolioCmd.AddCommand(portListCmd)
 }
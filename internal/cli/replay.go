@@ -0,0 +1,33 @@
+package cli
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ericyhkim/juga/pkg/replay"
+
+	"github.com/spf13/cobra"
+)
+
+// httpTransport returns the transport requested by --record or --replay, or nil to
+// use the network as usual.
+func httpTransport(cmd *cobra.Command) (http.RoundTripper, error) {
+	record, _ := cmd.Flags().GetString("record")
+	replayDir, _ := cmd.Flags().GetString("replay")
+
+	switch {
+	case record != "" && replayDir != "":
+		return nil, errors.New("--record cannot be combined with --replay")
+	case record != "":
+		return replay.NewRecorder(record, nil)
+	case replayDir != "":
+		return replay.NewReplayer(replayDir)
+	default:
+		return nil, nil
+	}
+}
+
+func init() {
+	rootCmd.PersistentFlags().String("record", "", "Save every HTTP request and response to this directory")
+	rootCmd.PersistentFlags().String("replay", "", "Answer HTTP requests from a directory saved with --record instead of the network")
+}
@@ -1,13 +1,20 @@
 package cli
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"strings"
 
+	"github.com/ericyhkim/juga/internal/output"
 	"github.com/ericyhkim/juga/internal/ui"
 	"github.com/ericyhkim/juga/pkg/config"
 	"github.com/ericyhkim/juga/pkg/diag"
+	"github.com/ericyhkim/juga/pkg/models"
 	"github.com/ericyhkim/juga/pkg/resolver"
+	"github.com/ericyhkim/juga/pkg/service"
 
 	"github.com/spf13/cobra"
 )
@@ -29,13 +36,31 @@ Deterministic Prefixes:
   #<code>   - Force Stock Code resolution
   /<query>  - Force Fuzzy Search (bypasses cache/aliases)
 
+Set Operators (followed by a prefixed operand, applied left to right):
+  @a+@b     - Stocks in either portfolio
+  @a-:sam   - Portfolio 'a' without alias 'sam'
+  @a&@b     - Stocks in both portfolios
+
 Example:
   juga 삼성전자 :sam #005930 @my-tech
-  juga /카카오`,
+  juga @tech+@bio-:sam
+  juga /카카오
+  juga --watch --interval 10s @my-tech
+  juga --explain tech 삼전`,
 	Args: cobra.ArbitraryArgs,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-		logger := diag.NewStdLogger()
-		deps, err := NewDependencies(logger)
+		if err := validateOutputFlags(cmd); err != nil {
+			return err
+		}
+
+		transport, err := httpTransport(cmd)
+		if err != nil {
+			return err
+		}
+
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		logger := diag.NewStdLogger(verbose)
+		deps, err := NewDependencies(logger, transport)
 		if err != nil {
 			return err
 		}
@@ -61,38 +86,30 @@ Example:
 
 		deps := GetDeps(cmd)
 
+		if explain, _ := cmd.Flags().GetBool("explain"); explain {
+			if outputFormat(cmd).IsMachine() || stockTemplate(cmd) != nil {
+				deps.Logger.Error("--explain cannot be combined with --output or --format.")
+				return
+			}
+			runExplain(deps, args)
+			return
+		}
+
 		results := deps.Resolver.ResolveAll(args)
 
 		finalResults := make([]resolver.ResolutionResult, 0, len(results))
 		for _, res := range results {
-			if res.IsAmbiguous {
-				listItems := make([]ui.ListItem, 0, len(res.Candidates))
-				for _, c := range res.Candidates {
-					listItems = append(listItems, ui.ListItem{
-						Key:   c.Name,
-						Value: c.Code,
-					})
-				}
-
-				title := fmt.Sprintf("Multiple matches for '%s'. Select one:", res.Input)
-				selectedCode, err := ui.RunPicker(title, listItems)
-				if err == nil {
-					for _, c := range res.Candidates {
-						if c.Code == selectedCode {
-							res.Code = c.Code
-							res.Name = c.Name
-							res.IsAmbiguous = false
-							break
-						}
-					}
-				}
-			}
-			finalResults = append(finalResults, res)
+			finalResults = append(finalResults, pickCandidate(deps, res))
 		}
 
-		for _, res := range finalResults {
-			if res.Status == resolver.StatusNotFound {
-				fmt.Printf("⚠️  Could not find stock for '%s'\n", res.Input)
+		format := outputFormat(cmd)
+		tmpl := stockTemplate(cmd)
+
+		if !format.IsMachine() && tmpl == nil {
+			for _, res := range finalResults {
+				if res.Status == resolver.StatusNotFound {
+					fmt.Printf("⚠️  Could not find stock for '%s'\n", res.Input)
+				}
 			}
 		}
 
@@ -100,34 +117,150 @@ Example:
 			deps.Logger.Error("Failed to save cache: %v", cacheErr)
 		}
 
-		fetchRes, err := deps.StockService.FetchStocks(finalResults)
+		if watch, _ := cmd.Flags().GetBool("watch"); watch {
+			if format.IsMachine() {
+				deps.Logger.Error("--watch cannot be combined with --output %s.", format)
+				return
+			}
+			if tmpl != nil {
+				deps.Logger.Error("--watch cannot be combined with --format.")
+				return
+			}
+			interval, _ := cmd.Flags().GetDuration("interval")
+			runWatch(cmd, deps, finalResults, interval)
+			return
+		}
+
+		limit, _ := cmd.Flags().GetInt("limit")
+		fetchRes, err := deps.StockService.FetchStocks(cmd.Context(), finalResults, limit)
+		if errors.Is(err, context.Canceled) {
+			return
+		}
 		if err != nil {
 			deps.Logger.Error("Error fetching data: %v", err)
 			return
 		}
 
+		if format.IsMachine() {
+			writeRecords(cmd, output.NewQuoteRecords(finalResults, fetchRes.Stocks))
+			if fetchRes.IsTruncated {
+				fmt.Fprintf(os.Stderr, "⚠️  Display limited to %d stocks by --limit. %d items were ignored.\n", limit, fetchRes.IgnoredCount)
+			}
+			return
+		}
+
+		if tmpl != nil {
+			writeTemplate(cmd, tmpl, fetchRes.Stocks)
+			return
+		}
+
 		if len(fetchRes.Stocks) == 0 {
 			return
 		}
 
 		presenter := ui.NewPresenter()
-		stockVMs := presenter.PrepareList(fetchRes.Stocks)
 
-		fmt.Println(ui.RenderStockTable(stockVMs))
+		if holdings := positionHoldings(deps, args); holdings != nil {
+			positions := service.BuildPositions(holdings, finalResults, fetchRes.Stocks, deps.Resolver)
+			fmt.Println(ui.RenderPositionTable(presenter.PreparePositions(positions)))
+		} else {
+			stockVMs := presenter.PrepareList(fetchRes.Stocks)
+			fmt.Println(ui.RenderStockTable(stockVMs))
+		}
 
 		if fetchRes.IsTruncated {
-			fmt.Fprintf(os.Stderr, "\n⚠️  Display limited to some stocks. %d items were ignored.\n", fetchRes.IgnoredCount)
+			fmt.Fprintf(os.Stderr, "\n⚠️  Display limited to %d stocks by --limit. %d items were ignored.\n", limit, fetchRes.IgnoredCount)
 		}
 	},
 }
 
+// positionHoldings returns the holdings of the queried portfolio when the command was
+// invoked with a single portfolio that records at least one position.
+func positionHoldings(deps *Dependencies, args []string) []models.Holding {
+	if len(args) != 1 {
+		return nil
+	}
+
+	name := strings.TrimPrefix(args[0], models.PrefixPortfolio)
+	holdings, err := deps.PortfolioService.GetHoldings(name)
+	if err != nil {
+		return nil
+	}
+
+	for _, h := range holdings {
+		if h.HasPosition() {
+			return holdings
+		}
+	}
+	return nil
+}
+
+// pickCandidate lets the user choose among the candidates of an ambiguous result.
+// The choice is cached for the input, and can be saved as an alias so it is always
+// used. Unambiguous results are returned as-is.
+func pickCandidate(deps *Dependencies, res resolver.ResolutionResult) resolver.ResolutionResult {
+	if !res.IsAmbiguous {
+		return res
+	}
+
+	listItems := make([]ui.ListItem, 0, len(res.Candidates))
+	for _, c := range res.Candidates {
+		listItems = append(listItems, ui.ListItem{
+			Key:   c.Name,
+			Value: c.Code,
+		})
+	}
+
+	nick := strings.TrimPrefix(res.Input, models.PrefixSearch)
+	title := fmt.Sprintf("Multiple matches for '%s'. Select one:", res.Input)
+	question := fmt.Sprintf("Always use this for '%s'? (saves an alias)", nick)
+	selectedCode, always, err := ui.RunPickerWithConfirm(title, listItems, question)
+	if err != nil {
+		return res
+	}
+
+	for _, c := range res.Candidates {
+		if c.Code == selectedCode {
+			res.Code = c.Code
+			res.Name = c.Name
+			res.IsAmbiguous = false
+			break
+		}
+	}
+	deps.Resolver.RecordChoice(res.Input, res.Code)
+
+	if always {
+		if _, err := deps.AliasService.SetAlias(nick, res.Code); err != nil {
+			deps.Logger.Error("Error saving alias: %v", err)
+		} else {
+			fmt.Fprintf(os.Stderr, "Alias set: %s -> %s (%s)\n", nick, res.Code, res.Name)
+		}
+	}
+	return res
+}
+
+func init() {
+	rootCmd.Flags().IntP("limit", "n", 0, "Show at most this many stocks (0 for all)")
+	rootCmd.PersistentFlags().Bool("verbose", false, "Print debug messages such as retries and provider failovers")
+}
+
 func Execute() {
 	if err := config.EnsureAppDirs(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error creating config directory: %v\n", err)
 		os.Exit(1)
 	}
 
-	if err := rootCmd.Execute(); err != nil {
+	// Ctrl-C cancels the command context so that pending requests are aborted.
+	// A second Ctrl-C falls back to the default behavior and kills the process.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	context.AfterFunc(ctx, stop)
+	defer stop()
+
+	err := rootCmd.ExecuteContext(ctx)
+	if ctx.Err() != nil {
+		os.Exit(130)
+	}
+	if err != nil {
 		os.Exit(1)
 	}
 }
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/ericyhkim/juga/internal/server"
+	"github.com/ericyhkim/juga/pkg/config"
+
+	"github.com/spf13/cobra"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve quotes as a local JSON API",
+	Long: `Starts an HTTP server exposing juga's data as JSON:
+
+  GET /quote?q=삼전,:sam,@tech   Quotes for names, aliases, codes and portfolios
+  GET /indices                   KOSPI and KOSDAQ
+  GET /search?q=삼성&limit=10     Ticker search
+  GET /portfolios                Portfolio holdings
+  GET /aliases                   Aliases
+
+Quote and index responses are cached in memory for --cache-ttl, so many local
+clients share a single request to Naver.`,
+	Example: `  juga serve
+  juga serve --addr :8080 --cache-ttl 10s`,
+	Run: func(cmd *cobra.Command, args []string) {
+		deps := GetDeps(cmd)
+
+		addr, _ := cmd.Flags().GetString("addr")
+		ttl, _ := cmd.Flags().GetDuration("cache-ttl")
+
+		srv := server.New(server.Config{
+			Resolver:   deps.Resolver,
+			Stocks:     deps.StockService,
+			Aliases:    deps.AliasService,
+			Portfolios: deps.PortfolioService,
+			Logger:     deps.Logger,
+			CacheTTL:   ttl,
+		})
+		httpServer := &http.Server{
+			Addr:              addr,
+			Handler:           srv.Handler(),
+			ReadHeaderTimeout: 5 * time.Second,
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+		defer stop()
+
+		go func() {
+			<-ctx.Done()
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_ = httpServer.Shutdown(shutdownCtx)
+		}()
+
+		fmt.Printf("Serving on http://%s (Ctrl-C to stop)\n", addr)
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			deps.Logger.Error("Server error: %v", err)
+			return
+		}
+
+		if err := deps.Cache.Save(); err != nil {
+			deps.Logger.Error("Failed to save cache: %v", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	serveCmd.Flags().String("addr", config.DefaultServeAddr, "Address to listen on")
+	serveCmd.Flags().Duration("cache-ttl", config.DefaultServeCacheTTL, "How long quote responses are reused")
+}
@@ -0,0 +1,240 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ericyhkim/juga/internal/ui"
+	"github.com/ericyhkim/juga/pkg/models"
+	"github.com/ericyhkim/juga/pkg/service"
+
+	"github.com/spf13/cobra"
+)
+
+var txCmd = &cobra.Command{
+	Use:     "tx",
+	Aliases: []string{"t", "ledger"},
+	Short:   "Record trades and dividends in a portfolio ledger",
+	Long: `Each portfolio can keep an append-only ledger of buys, sells and dividends.
+Positions (quantity and average cost) are derived from the ledger using average-cost accounting
+and written back to the portfolio, so 'juga @<name>' shows the resulting P&L.
+Broker fees and the securities transaction tax are computed per portfolio (see 'juga tx config').`,
+	Example: `  juga tx buy tech 삼성전자 10 71500
+  juga tx sell tech 삼성전자 5 80000 --date 2024-03-02
+  juga tx dividend tech 삼성전자 10 361
+  juga tx report tech`,
+}
+
+var txBuyCmd = newTxRecordCmd(models.TxBuy, "buy", nil, "Record a purchase", "<price>")
+var txSellCmd = newTxRecordCmd(models.TxSell, "sell", nil, "Record a sale", "<price>")
+var txDividendCmd = newTxRecordCmd(models.TxDividend, "dividend", []string{"div"}, "Record a dividend payment", "<amount_per_share>")
+
+func newTxRecordCmd(txType models.TransactionType, use string, aliases []string, short, priceArg string) *cobra.Command {
+	usage := fmt.Sprintf("juga tx %s <portfolio> <stock> <quantity> %s [--date YYYY-MM-DD]", use, priceArg)
+
+	cmd := &cobra.Command{
+		Use:     fmt.Sprintf("%s <portfolio> <stock> <quantity> %s", use, priceArg),
+		Aliases: aliases,
+		Short:   short,
+		Args:    cobra.ArbitraryArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) < 4 {
+				fmt.Println(ui.RenderContextualHelp(ui.ContextualHelp{
+					Usage: usage,
+					Examples: []string{
+						fmt.Sprintf("juga tx %s tech 삼성전자 10 71500", use),
+						fmt.Sprintf("juga tx %s tech :sam 10 71500 --date 2024-01-15", use),
+					},
+					ErrorMessage: "Please provide a portfolio, a stock, a quantity and a price.",
+				}))
+				return
+			}
+
+			portfolio := strings.TrimPrefix(args[0], models.PrefixPortfolio)
+
+			deps := GetDeps(cmd)
+
+			quantity, err := service.ParseAmount(args[2])
+			if err != nil {
+				deps.Logger.Error("Invalid quantity '%s'.", args[2])
+				return
+			}
+			price, err := service.ParseAmount(args[3])
+			if err != nil {
+				deps.Logger.Error("Invalid price '%s'.", args[3])
+				return
+			}
+
+			now := time.Now()
+			date := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+			if dateFlag, _ := cmd.Flags().GetString("date"); dateFlag != "" {
+				date, err = time.ParseInLocation("2006-01-02", dateFlag, time.Local)
+				if err != nil {
+					deps.Logger.Error("Invalid date '%s'. Use YYYY-MM-DD.", dateFlag)
+					return
+				}
+			}
+
+			res, ok := resolveSingle(deps, args[1])
+			if !ok {
+				return
+			}
+
+			op, err := deps.LedgerService.Record(portfolio, txType, res, quantity, price, date)
+			if err != nil {
+				switch {
+				case errors.Is(err, service.ErrInsufficientQuantity):
+					deps.Logger.Error("Cannot record sale: %v", err)
+				case errors.Is(err, service.ErrInvalidTransaction):
+					deps.Logger.Error("Quantity must be positive and price must not be negative.")
+				default:
+					deps.Logger.Error("Error recording transaction: %v", err)
+				}
+				return
+			}
+
+			presenter := ui.NewPresenter()
+			fmt.Println(ui.RenderListTable(presenter.PrepareLedger([]models.Transaction{op.Transaction})))
+			if txType != models.TxDividend {
+				if op.Position.Quantity == 0 {
+					fmt.Printf("Position in '%s' is now closed.\n", op.Portfolio)
+				} else {
+					fmt.Printf("Position in '%s': %g shares @ %.2f\n", op.Portfolio, op.Position.Quantity, op.Position.AvgPrice)
+				}
+			}
+		},
+	}
+
+	cmd.Flags().String("date", "", "Trade date (YYYY-MM-DD, defaults to today)")
+	return cmd
+}
+
+var txListCmd = &cobra.Command{
+	Use:     "list <portfolio>",
+	Aliases: []string{"ls"},
+	Short:   "List the transactions of a portfolio",
+	Args:    cobra.ArbitraryArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			fmt.Println(ui.RenderContextualHelp(ui.ContextualHelp{
+				Usage:        "juga tx list <portfolio>",
+				Examples:     []string{"juga tx list tech"},
+				ErrorMessage: "Please specify the portfolio.",
+			}))
+			return
+		}
+
+		portfolio := strings.TrimPrefix(args[0], models.PrefixPortfolio)
+
+		deps := GetDeps(cmd)
+		txs := deps.LedgerService.Transactions(portfolio)
+		if len(txs) == 0 {
+			fmt.Printf("No transactions recorded for '%s'.\n", portfolio)
+			return
+		}
+
+		presenter := ui.NewPresenter()
+		fmt.Println(ui.RenderListTable(presenter.PrepareLedger(txs)))
+	},
+}
+
+var txReportCmd = &cobra.Command{
+	Use:     "report <portfolio>",
+	Aliases: []string{"r"},
+	Short:   "Show realized gains, dividend income, fees and taxes",
+	Args:    cobra.ArbitraryArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			fmt.Println(ui.RenderContextualHelp(ui.ContextualHelp{
+				Usage:        "juga tx report <portfolio>",
+				Examples:     []string{"juga tx report tech"},
+				ErrorMessage: "Please specify the portfolio.",
+			}))
+			return
+		}
+
+		portfolio := strings.TrimPrefix(args[0], models.PrefixPortfolio)
+
+		deps := GetDeps(cmd)
+		report, err := deps.LedgerService.Report(portfolio)
+		if err != nil {
+			if errors.Is(err, service.ErrNotFound) {
+				fmt.Printf("No transactions recorded for '%s'.\n", portfolio)
+			} else {
+				deps.Logger.Error("Error building report: %v", err)
+			}
+			return
+		}
+
+		presenter := ui.NewPresenter()
+		fmt.Println(ui.RenderListTable(presenter.PrepareLedgerReport(report)))
+	},
+}
+
+var txConfigCmd = &cobra.Command{
+	Use:   "config <portfolio>",
+	Short: "Show or change the fee and tax rates of a portfolio",
+	Long: `Shows the broker fee and securities transaction tax rates used for new transactions.
+Rates are given in percent. Existing transactions keep the costs computed when they were recorded.`,
+	Example: `  juga tx config tech
+  juga tx config tech --fee 0.015 --tax 0.20
+  juga tx config etf --tax 0`,
+	Args: cobra.ArbitraryArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			fmt.Println(ui.RenderContextualHelp(ui.ContextualHelp{
+				Usage: "juga tx config <portfolio> [--fee <percent>] [--tax <percent>]",
+				Examples: []string{
+					"juga tx config tech --fee 0.015 --tax 0.20",
+					"juga tx config etf --tax 0       # ETFs are exempt from transaction tax",
+				},
+				ErrorMessage: "Please specify the portfolio.",
+			}))
+			return
+		}
+
+		portfolio := strings.TrimPrefix(args[0], models.PrefixPortfolio)
+
+		deps := GetDeps(cmd)
+		settings := deps.LedgerService.Settings(portfolio)
+
+		changed := false
+		if cmd.Flags().Changed("fee") {
+			fee, _ := cmd.Flags().GetFloat64("fee")
+			settings.FeeRate = fee / 100
+			changed = true
+		}
+		if cmd.Flags().Changed("tax") {
+			tax, _ := cmd.Flags().GetFloat64("tax")
+			settings.TaxRate = tax / 100
+			changed = true
+		}
+
+		if changed {
+			if err := deps.LedgerService.SetSettings(portfolio, settings); err != nil {
+				deps.Logger.Error("Error saving settings: %v", err)
+				return
+			}
+		}
+
+		fmt.Println(ui.RenderListTable([]ui.ListItem{
+			{Key: "Broker fee", Value: fmt.Sprintf("%g%%", settings.FeeRate*100)},
+			{Key: "Transaction tax", Value: fmt.Sprintf("%g%% (sells only)", settings.TaxRate*100)},
+		}))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(txCmd)
+	txCmd.AddCommand(txBuyCmd)
+	txCmd.AddCommand(txSellCmd)
+	txCmd.AddCommand(txDividendCmd)
+	txCmd.AddCommand(txListCmd)
+	txCmd.AddCommand(txReportCmd)
+	txCmd.AddCommand(txConfigCmd)
+
+	txConfigCmd.Flags().Float64("fee", 0, "Broker fee rate in percent (e.g. 0.015)")
+	txConfigCmd.Flags().Float64("tax", 0, "Securities transaction tax rate in percent (e.g. 0.20)")
+}
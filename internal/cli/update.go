@@ -24,6 +24,13 @@ Process takes about 10-20 seconds.`,
 		}
 
 		fmt.Printf("✅ Successfully updated %d tickers.\n", res.Count)
+
+		if removed := deps.Resolver.RevalidateCache(); removed > 0 {
+			fmt.Printf("Removed %d stale search cache entries.\n", removed)
+		}
+		if err := deps.Cache.Save(); err != nil {
+			deps.Logger.Error("Failed to save cache: %v", err)
+		}
 	},
 }
 
@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ericyhkim/juga/internal/ui"
+	"github.com/ericyhkim/juga/pkg/config"
+	"github.com/ericyhkim/juga/pkg/models"
+	"github.com/ericyhkim/juga/pkg/resolver"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	ansiCursorUp   = "\033[%dA"
+	ansiClearBelow = "\033[J"
+)
+
+// runWatch refreshes the quotes of already resolved stocks until interrupted,
+// redrawing the table in place. While the market is closed the refresh interval
+// doubles on every tick, up to config.MaxWatchInterval.
+func runWatch(cmd *cobra.Command, deps *Dependencies, results []resolver.ResolutionResult, interval time.Duration) {
+	if interval <= 0 {
+		interval = config.DefaultWatchInterval
+	}
+
+	limit, _ := cmd.Flags().GetInt("limit")
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	presenter := ui.NewPresenter()
+	previous := make(map[string]float64)
+	drawnLines := 0
+	wait := interval
+
+	for {
+		var sb strings.Builder
+		var notes []string
+
+		fetchRes, err := deps.StockService.FetchStocks(ctx, results, limit)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			sb.WriteString(ui.StyleHelpError.Render(fmt.Sprintf("Error fetching data: %v", err)))
+		} else {
+			sb.WriteString(ui.RenderStockTable(presenter.PrepareWatchList(fetchRes.Stocks, previous)))
+
+			for _, s := range fetchRes.Stocks {
+				previous[s.Code] = s.Price
+			}
+
+			if anyMarketOpen(fetchRes.Stocks) {
+				wait = interval
+			} else {
+				wait = min(wait*2, config.MaxWatchInterval)
+				notes = append(notes, "Market closed")
+			}
+
+			if fetchRes.IsTruncated {
+				notes = append(notes, fmt.Sprintf("%d items ignored", fetchRes.IgnoredCount))
+			}
+		}
+
+		notes = append(notes,
+			"Updated "+time.Now().Format("15:04:05"),
+			fmt.Sprintf("next refresh in %s", wait),
+			"Ctrl-C to quit",
+		)
+		sb.WriteString("\n\n" + ui.StyleNameInactive.Render(strings.Join(notes, " · ")))
+
+		frame := sb.String()
+		if drawnLines > 0 {
+			fmt.Printf(ansiCursorUp+"\r"+ansiClearBelow, drawnLines)
+		}
+		fmt.Println(frame)
+		drawnLines = screenLines(frame, ui.TerminalWidth())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// screenLines counts the terminal rows a frame occupies, including wrapped lines.
+func screenLines(frame string, width int) int {
+	rows := 0
+	for _, line := range strings.Split(frame, "\n") {
+		w := lipgloss.Width(line)
+		if w <= width || width <= 0 {
+			rows++
+			continue
+		}
+		rows += (w + width - 1) / width
+	}
+	return rows
+}
+
+func anyMarketOpen(stocks []models.Stock) bool {
+	for _, s := range stocks {
+		if s.IsMarketOpen() {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	rootCmd.Flags().BoolP("watch", "w", false, "Keep refreshing quotes in place")
+	rootCmd.Flags().Duration("interval", config.DefaultWatchInterval, "Refresh interval in watch mode")
+}
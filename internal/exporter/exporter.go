@@ -0,0 +1,226 @@
+// Package exporter publishes quotes of watched stocks and market indices as
+// Prometheus metrics in the text exposition format.
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ericyhkim/juga/pkg/diag"
+	"github.com/ericyhkim/juga/pkg/models"
+	"github.com/ericyhkim/juga/pkg/resolver"
+	"github.com/ericyhkim/juga/pkg/service"
+)
+
+const (
+	targetStocks  = "stocks"
+	targetIndices = "indices"
+)
+
+// Config holds the inputs watched by the exporter and the services used to fetch them.
+type Config struct {
+	Resolver *resolver.Resolver
+	Stocks   *service.StockService
+	// Inputs are names, codes, aliases or portfolios, resolved on every refresh so
+	// edits to a portfolio show up without a restart.
+	Inputs   []string
+	Interval time.Duration
+	Logger   diag.Logger
+}
+
+type stockSample struct {
+	stock  models.Stock
+	market string
+}
+
+type fetchStats struct {
+	errors      uint64
+	duration    time.Duration
+	lastSuccess time.Time
+}
+
+type Exporter struct {
+	cfg Config
+
+	mu      sync.RWMutex
+	stocks  []stockSample
+	indices []models.Stock
+	stats   map[string]*fetchStats
+}
+
+func New(cfg Config) *Exporter {
+	return &Exporter{
+		cfg: cfg,
+		stats: map[string]*fetchStats{
+			targetStocks:  {},
+			targetIndices: {},
+		},
+	}
+}
+
+// Run refreshes the metrics every Config.Interval until ctx is cancelled.
+func (e *Exporter) Run(ctx context.Context) {
+	for {
+		e.Refresh(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(e.cfg.Interval):
+		}
+	}
+}
+
+// Refresh fetches quotes and indices once. A failed fetch keeps the previous samples
+// and counts towards juga_fetch_errors_total, unless ctx was cancelled.
+func (e *Exporter) Refresh(ctx context.Context) {
+	results := e.cfg.Resolver.ResolveAll(e.cfg.Inputs)
+
+	start := time.Now()
+	fetchRes, err := e.cfg.Stocks.FetchStocks(ctx, results, 0)
+	if ctx.Err() != nil {
+		return
+	}
+	e.record(targetStocks, time.Since(start), err)
+	if err != nil {
+		e.cfg.Logger.Warn("Failed to fetch stocks: %v", err)
+	} else {
+		samples := make([]stockSample, 0, len(fetchRes.Stocks))
+		for _, s := range fetchRes.Stocks {
+			t, _ := e.cfg.Resolver.TickerOf(s.Code)
+			samples = append(samples, stockSample{stock: s, market: t.Market})
+		}
+		e.mu.Lock()
+		e.stocks = samples
+		e.mu.Unlock()
+	}
+
+	start = time.Now()
+	indices, err := e.cfg.Stocks.FetchIndices(ctx)
+	if ctx.Err() != nil {
+		return
+	}
+	e.record(targetIndices, time.Since(start), err)
+	if err != nil {
+		e.cfg.Logger.Warn("Failed to fetch indices: %v", err)
+	} else {
+		e.mu.Lock()
+		e.indices = indices
+		e.mu.Unlock()
+	}
+}
+
+func (e *Exporter) record(target string, d time.Duration, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	st := e.stats[target]
+	st.duration = d
+	if err != nil {
+		st.errors++
+		return
+	}
+	st.lastSuccess = time.Now()
+}
+
+// ServeHTTP writes the current metrics.
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	e.WriteMetrics(w)
+}
+
+// WriteMetrics renders all metrics in the Prometheus text exposition format.
+func (e *Exporter) WriteMetrics(w io.Writer) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	m := &metricWriter{w: w}
+
+	stockGauge := func(name, help string, value func(models.Stock) float64) {
+		m.header(name, "gauge", help)
+		for _, s := range e.stocks {
+			m.sample(name, value(s.stock), "code", s.stock.Code, "name", s.stock.Name, "market", s.market)
+		}
+	}
+	stockGauge("juga_stock_price", "Current price in KRW.", func(s models.Stock) float64 { return s.Price })
+	stockGauge("juga_stock_change_percent", "Change from the previous close in percent.", func(s models.Stock) float64 { return s.ChangePercent })
+	stockGauge("juga_stock_trading_value", "Accumulated trading value in millions of KRW.", func(s models.Stock) float64 { return s.TradingValue })
+	stockGauge("juga_stock_market_open", "1 while the stock's market is open.", func(s models.Stock) float64 { return boolValue(s.IsMarketOpen()) })
+
+	indexGauge := func(name, help string, value func(models.Stock) float64) {
+		m.header(name, "gauge", help)
+		for _, s := range e.indices {
+			m.sample(name, value(s), "code", s.Code, "name", s.Name)
+		}
+	}
+	indexGauge("juga_index_value", "Current index value.", func(s models.Stock) float64 { return s.Price })
+	indexGauge("juga_index_change_percent", "Index change from the previous close in percent.", func(s models.Stock) float64 { return s.ChangePercent })
+	indexGauge("juga_index_trading_value", "Accumulated index trading value in millions of KRW.", func(s models.Stock) float64 { return s.TradingValue })
+
+	targets := make([]string, 0, len(e.stats))
+	for t := range e.stats {
+		targets = append(targets, t)
+	}
+	sort.Strings(targets)
+
+	m.header("juga_fetch_errors_total", "counter", "Failed fetches from Naver.")
+	for _, t := range targets {
+		m.sample("juga_fetch_errors_total", float64(e.stats[t].errors), "target", t)
+	}
+	m.header("juga_fetch_duration_seconds", "gauge", "Duration of the last fetch from Naver.")
+	for _, t := range targets {
+		m.sample("juga_fetch_duration_seconds", e.stats[t].duration.Seconds(), "target", t)
+	}
+	m.header("juga_fetch_last_success_timestamp_seconds", "gauge", "Unix time of the last successful fetch.")
+	for _, t := range targets {
+		var ts float64
+		if last := e.stats[t].lastSuccess; !last.IsZero() {
+			ts = float64(last.UnixMilli()) / 1000
+		}
+		m.sample("juga_fetch_last_success_timestamp_seconds", ts, "target", t)
+	}
+}
+
+type metricWriter struct {
+	w io.Writer
+}
+
+func (m *metricWriter) header(name, kind, help string) {
+	fmt.Fprintf(m.w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+// sample writes one line; labels are given as alternating names and values.
+func (m *metricWriter) sample(name string, value float64, labels ...string) {
+	var sb strings.Builder
+	sb.WriteString(name)
+	if len(labels) > 0 {
+		sb.WriteByte('{')
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			fmt.Fprintf(&sb, "%s=\"%s\"", labels[i], escapeLabel(labels[i+1]))
+		}
+		sb.WriteByte('}')
+	}
+	fmt.Fprintf(m.w, "%s %g\n", sb.String(), value)
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabel(v string) string {
+	return labelEscaper.Replace(v)
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,98 @@
+package exporter
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ericyhkim/juga/pkg/diag"
+	"github.com/ericyhkim/juga/pkg/models"
+	"github.com/ericyhkim/juga/pkg/quote/quotetest"
+	"github.com/ericyhkim/juga/pkg/resolver"
+	"github.com/ericyhkim/juga/pkg/service"
+	"github.com/ericyhkim/juga/pkg/storage"
+)
+
+func newProvider() *quotetest.Provider {
+	return &quotetest.Provider{
+		Stock: func(code string) models.Stock {
+			return models.Stock{Code: code, Name: `Quote"d`, Price: 71500, ChangePercent: -1.25, TradingValue: 1200, MarketStatus: "OPEN"}
+		},
+		Indices: []models.Stock{{Code: "KOSPI", Name: "코스피", Price: 2650.5, ChangePercent: 0.4}},
+	}
+}
+
+func newTestExporter(t *testing.T, client *quotetest.Provider) *Exporter {
+	t.Helper()
+	dir := t.TempDir()
+	logger := diag.NewNopLogger()
+
+	portfolios := storage.NewPortfolioRepository(filepath.Join(dir, "portfolios.json"), logger)
+	if err := portfolios.Add("tech", []models.Holding{{Item: "005930"}}); err != nil {
+		t.Fatal(err)
+	}
+	tickers := storage.NewTickerRepository(filepath.Join(dir, "master_tickers.csv"), logger)
+	res := resolver.NewResolver(
+		portfolios,
+		storage.NewAliasRepository(filepath.Join(dir, "aliases.json"), logger),
+		storage.NewCacheRepository(filepath.Join(dir, "cache.json"), 10, logger),
+		tickers,
+		logger,
+	)
+
+	return New(Config{
+		Resolver: res,
+		Stocks:   service.NewStockService(tickers, client, logger, time.Second),
+		Inputs:   []string{"@tech"},
+		Interval: time.Minute,
+		Logger:   logger,
+	})
+}
+
+func TestWriteMetrics(t *testing.T) {
+	client := newProvider()
+	e := newTestExporter(t, client)
+	e.Refresh(context.Background())
+
+	var sb strings.Builder
+	e.WriteMetrics(&sb)
+	out := sb.String()
+
+	for _, want := range []string{
+		"# TYPE juga_stock_price gauge",
+		`juga_stock_price{code="005930",name="Quote\"d",market="KOSPI"} 71500`,
+		`juga_stock_change_percent{code="005930",name="Quote\"d",market="KOSPI"} -1.25`,
+		`juga_stock_trading_value{code="005930",name="Quote\"d",market="KOSPI"} 1200`,
+		`juga_stock_market_open{code="005930",name="Quote\"d",market="KOSPI"} 1`,
+		`juga_index_value{code="KOSPI",name="코스피"} 2650.5`,
+		`juga_fetch_errors_total{target="stocks"} 0`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected metrics to contain %q\n%s", want, out)
+		}
+	}
+}
+
+func TestRefresh_KeepsSamplesOnError(t *testing.T) {
+	client := newProvider()
+	e := newTestExporter(t, client)
+	e.Refresh(context.Background())
+
+	client.Fail(errors.New("boom"))
+	e.Refresh(context.Background())
+
+	var sb strings.Builder
+	e.WriteMetrics(&sb)
+	out := sb.String()
+
+	if !strings.Contains(out, `juga_fetch_errors_total{target="stocks"} 1`) ||
+		!strings.Contains(out, `juga_fetch_errors_total{target="indices"} 1`) {
+		t.Errorf("Expected one error per target\n%s", out)
+	}
+	if !strings.Contains(out, "juga_stock_price{") {
+		t.Errorf("Expected previous samples to be kept after a failed fetch")
+	}
+}
@@ -0,0 +1,214 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format selects how command results are printed.
+type Format string
+
+const (
+	FormatText Format = "text"
+	FormatJSON Format = "json"
+	FormatCSV  Format = "csv"
+	FormatTSV  Format = "tsv"
+)
+
+// ParseFormat validates a user supplied --output value. An empty value means FormatText.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(strings.TrimSpace(s))); f {
+	case "":
+		return FormatText, nil
+	case FormatText, FormatJSON, FormatCSV, FormatTSV:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unsupported output format '%s' (use text, json, csv or tsv)", s)
+	}
+}
+
+// IsMachine reports whether the format is meant for scripts rather than humans.
+func (f Format) IsMachine() bool {
+	return f != FormatText && f != ""
+}
+
+// Write encodes records, a slice of structs, in the given format. Field names are taken
+// from the `json` struct tags so all formats share the same stable names. Embedded structs
+// are flattened; in CSV/TSV, nested slices, maps and structs are encoded as JSON.
+func Write(w io.Writer, format Format, records interface{}) error {
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		return enc.Encode(records)
+	case FormatCSV:
+		return writeDelimited(w, ',', records)
+	case FormatTSV:
+		return writeDelimited(w, '\t', records)
+	default:
+		return fmt.Errorf("format '%s' is not a machine-readable format", format)
+	}
+}
+
+func writeDelimited(w io.Writer, comma rune, records interface{}) error {
+	v := reflect.ValueOf(records)
+	if v.Kind() != reflect.Slice {
+		return fmt.Errorf("expected a slice of records, got %T", records)
+	}
+
+	elemType := v.Type().Elem()
+	for elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return fmt.Errorf("expected a slice of structs, got %T", records)
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+
+	if err := cw.Write(columnNames(elemType)); err != nil {
+		return err
+	}
+
+	for i := 0; i < v.Len(); i++ {
+		row := reflect.Indirect(v.Index(i))
+		if err := cw.Write(columnValues(row)); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+type column struct {
+	name  string
+	index []int
+}
+
+func columns(t reflect.Type) []column {
+	var cols []column
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		tag := f.Tag.Get("json")
+		name := strings.Split(tag, ",")[0]
+		if name == "-" {
+			continue
+		}
+
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			for _, c := range columns(ft) {
+				cols = append(cols, column{name: c.name, index: append([]int{i}, c.index...)})
+			}
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+		cols = append(cols, column{name: name, index: []int{i}})
+	}
+	return cols
+}
+
+// uniqueColumns drops columns shadowed by an earlier one with the same name, so that an
+// outer field wins over a promoted field of an embedded struct (as in encoding/json).
+func uniqueColumns(t reflect.Type) []column {
+	seen := make(map[string]bool)
+	var cols []column
+	for _, c := range columns(t) {
+		if seen[c.name] {
+			continue
+		}
+		seen[c.name] = true
+		cols = append(cols, c)
+	}
+	return cols
+}
+
+func columnNames(t reflect.Type) []string {
+	cols := uniqueColumns(t)
+	names := make([]string, len(cols))
+	for i, c := range cols {
+		names[i] = c.name
+	}
+	return names
+}
+
+func columnValues(row reflect.Value) []string {
+	cols := uniqueColumns(row.Type())
+	values := make([]string, len(cols))
+	for i, c := range cols {
+		values[i] = formatValue(fieldByIndex(row, c.index))
+	}
+	return values
+}
+
+// fieldByIndex is reflect.Value.FieldByIndex that yields an invalid value instead of
+// panicking when traversing a nil embedded pointer.
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for _, i := range index {
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	return v
+}
+
+func formatValue(v reflect.Value) string {
+	if !v.IsValid() {
+		return ""
+	}
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+
+	if t, ok := v.Interface().(time.Time); ok {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	default:
+		data, err := json.Marshal(v.Interface())
+		if err != nil {
+			return ""
+		}
+		return string(data)
+	}
+}
@@ -0,0 +1,95 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ericyhkim/juga/pkg/models"
+	"github.com/ericyhkim/juga/pkg/resolver"
+)
+
+func sampleQuoteRecords() []QuoteRecord {
+	results := []resolver.ResolutionResult{
+		{Input: "삼전", Code: "005930", Source: resolver.SourceSearch, Status: resolver.StatusSuccess},
+		{Input: "xyz", Status: resolver.StatusNotFound, Error: errors.New("stock not found: xyz")},
+	}
+	stocks := []models.Stock{
+		{Code: "005930", Name: "삼성전자", Price: 75000, ChangePercent: 1.5, IsRising: true},
+	}
+	return NewQuoteRecords(results, stocks)
+}
+
+func TestParseFormat(t *testing.T) {
+	if f, err := ParseFormat(""); err != nil || f != FormatText {
+		t.Errorf("Expected empty format to mean text, got %q (%v)", f, err)
+	}
+	if f, err := ParseFormat("JSON"); err != nil || f != FormatJSON {
+		t.Errorf("Expected json, got %q (%v)", f, err)
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
+
+func TestWrite_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatJSON, sampleQuoteRecords()); err != nil {
+		t.Fatalf("Write() returned error: %v", err)
+	}
+
+	var decoded []map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\n%s", err, buf.String())
+	}
+
+	if decoded[0]["input"] != "삼전" || decoded[0]["source"] != "Search" || decoded[0]["price"] != 75000.0 {
+		t.Errorf("Unexpected first record: %v", decoded[0])
+	}
+	if decoded[1]["status"] != "NotFound" || decoded[1]["error"] == nil {
+		t.Errorf("Unexpected second record: %v", decoded[1])
+	}
+	if _, ok := decoded[1]["price"]; ok {
+		t.Errorf("Expected no quote fields for unresolved input: %v", decoded[1])
+	}
+}
+
+func TestWrite_CSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatCSV, sampleQuoteRecords()); err != nil {
+		t.Fatalf("Write() returned error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected header + 2 rows, got %d:\n%s", len(lines), buf.String())
+	}
+
+	header := "input,source,status,error,code,name,price,change,change_percent,high,low,trading_value,is_rising,is_falling,market_status"
+	if lines[0] != header {
+		t.Errorf("Unexpected header:\n got  %s\n want %s", lines[0], header)
+	}
+	if !strings.HasPrefix(lines[1], "삼전,Search,Success,,005930,삼성전자,75000,0,1.5,") {
+		t.Errorf("Unexpected first row: %s", lines[1])
+	}
+	if !strings.HasPrefix(lines[2], "xyz,None,NotFound,stock not found: xyz,,,") {
+		t.Errorf("Unexpected second row: %s", lines[2])
+	}
+}
+
+func TestWrite_TSV(t *testing.T) {
+	var buf bytes.Buffer
+	records := []PortfolioRecord{
+		{Portfolio: "tech", Holding: models.Holding{Item: "삼전", Quantity: 10, AvgPrice: 70000}},
+	}
+	if err := Write(&buf, FormatTSV, records); err != nil {
+		t.Fatalf("Write() returned error: %v", err)
+	}
+
+	expected := "portfolio\titem\tquantity\tavg_price\ntech\t삼전\t10\t70000\n"
+	if buf.String() != expected {
+		t.Errorf("Unexpected TSV:\n%q\nwant\n%q", buf.String(), expected)
+	}
+}
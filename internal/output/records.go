@@ -0,0 +1,63 @@
+package output
+
+import (
+	"github.com/ericyhkim/juga/pkg/models"
+	"github.com/ericyhkim/juga/pkg/resolver"
+)
+
+// QuoteRecord is one input of the root command: how it was resolved and, when
+// found, its quote. Stock is nil for inputs that could not be resolved or fetched.
+type QuoteRecord struct {
+	Input  string                    `json:"input"`
+	Source resolver.ResolutionSource `json:"source"`
+	Status resolver.ResolutionStatus `json:"status"`
+	Error  string                    `json:"error,omitempty"`
+	Code   string                    `json:"code"`
+	*models.Stock
+}
+
+type AliasRecord struct {
+	Alias string `json:"alias"`
+	Code  string `json:"code"`
+}
+
+type PortfolioRecord struct {
+	Portfolio string `json:"portfolio"`
+	models.Holding
+}
+
+// CacheRecord is a search cache entry with the name of the cached ticker.
+type CacheRecord struct {
+	models.CacheEntry
+	Name string `json:"name"`
+}
+
+// NewQuoteRecords pairs resolution results with fetched stocks, keeping the order of results.
+func NewQuoteRecords(results []resolver.ResolutionResult, stocks []models.Stock) []QuoteRecord {
+	byCode := make(map[string]models.Stock, len(stocks))
+	for _, s := range stocks {
+		byCode[s.Code] = s
+	}
+
+	records := make([]QuoteRecord, 0, len(results))
+	for _, res := range results {
+		rec := QuoteRecord{
+			Input:  res.Input,
+			Source: res.Source,
+			Status: res.Status,
+			Code:   res.Code,
+		}
+		if rec.Source == "" {
+			rec.Source = resolver.SourceNone
+		}
+		if res.Error != nil {
+			rec.Error = res.Error.Error()
+		}
+		if s, ok := byCode[res.Code]; ok && res.Status == resolver.StatusSuccess {
+			stock := s
+			rec.Stock = &stock
+		}
+		records = append(records, rec)
+	}
+	return records
+}
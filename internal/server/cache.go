@@ -0,0 +1,83 @@
+package server
+
+import (
+	"sync"
+	"time"
+)
+
+// responseCache keeps upstream results for a short TTL. Concurrent requests for the
+// same key while a fetch is in flight wait for that fetch instead of starting their own.
+// Expired entries are dropped on every miss, since keys are chosen by clients.
+type responseCache struct {
+	ttl     time.Duration
+	now     func() time.Time
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+}
+
+type cacheEntry struct {
+	ready   chan struct{}
+	value   any
+	err     error
+	expires time.Time
+}
+
+func newResponseCache(ttl time.Duration) *responseCache {
+	return &responseCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]*cacheEntry),
+	}
+}
+
+// get returns the cached value for key, calling fetch on a miss. Errors are not cached.
+func (c *responseCache) get(key string, fetch func() (any, error)) (any, error) {
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok {
+		select {
+		case <-e.ready:
+			if c.now().Before(e.expires) {
+				c.mu.Unlock()
+				return e.value, nil
+			}
+		default:
+			c.mu.Unlock()
+			<-e.ready
+			return e.value, e.err
+		}
+	}
+
+	c.sweep()
+	e := &cacheEntry{ready: make(chan struct{})}
+	c.entries[key] = e
+	c.mu.Unlock()
+
+	e.value, e.err = fetch()
+	e.expires = c.now().Add(c.ttl)
+	close(e.ready)
+
+	if e.err != nil {
+		c.mu.Lock()
+		if c.entries[key] == e {
+			delete(c.entries, key)
+		}
+		c.mu.Unlock()
+	}
+
+	return e.value, e.err
+}
+
+// sweep deletes the expired entries. Entries still being fetched are kept. c.mu must
+// be held.
+func (c *responseCache) sweep() {
+	now := c.now()
+	for key, e := range c.entries {
+		select {
+		case <-e.ready:
+			if !now.Before(e.expires) {
+				delete(c.entries, key)
+			}
+		default:
+		}
+	}
+}
@@ -0,0 +1,201 @@
+// Package server exposes quotes, indices, ticker search, portfolios and aliases as a
+// local JSON API so other tools can share one source of Naver data.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ericyhkim/juga/internal/output"
+	"github.com/ericyhkim/juga/pkg/diag"
+	"github.com/ericyhkim/juga/pkg/models"
+	"github.com/ericyhkim/juga/pkg/resolver"
+	"github.com/ericyhkim/juga/pkg/service"
+)
+
+const defaultSearchLimit = 10
+
+// Config holds the services the server reads from.
+type Config struct {
+	Resolver   *resolver.Resolver
+	Stocks     *service.StockService
+	Aliases    *service.AliasService
+	Portfolios *service.PortfolioService
+	Logger     diag.Logger
+	// CacheTTL is how long quote and index responses are reused.
+	CacheTTL time.Duration
+}
+
+type Server struct {
+	cfg   Config
+	cache *responseCache
+	// resolveMu serializes resolution and search, which load the ticker database
+	// lazily and update the shared search cache.
+	resolveMu sync.Mutex
+}
+
+func New(cfg Config) *Server {
+	return &Server{
+		cfg:   cfg,
+		cache: newResponseCache(cfg.CacheTTL),
+	}
+}
+
+// Handler returns the routes of the API.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /quote", s.handleQuote)
+	mux.HandleFunc("GET /indices", s.handleIndices)
+	mux.HandleFunc("GET /search", s.handleSearch)
+	mux.HandleFunc("GET /portfolios", s.handlePortfolios)
+	mux.HandleFunc("GET /aliases", s.handleAliases)
+	return mux
+}
+
+// handleQuote resolves a comma-separated list of names, aliases, codes and portfolios,
+// e.g. /quote?q=삼전,:sam,@tech, and returns one record per resolved input.
+func (s *Server) handleQuote(w http.ResponseWriter, r *http.Request) {
+	inputs := splitQuery(r.URL.Query().Get("q"))
+	if len(inputs) == 0 {
+		writeError(w, http.StatusBadRequest, "missing query parameter 'q'")
+		return
+	}
+
+	s.resolveMu.Lock()
+	results := s.cfg.Resolver.ResolveAll(inputs)
+	s.resolveMu.Unlock()
+
+	var codes []string
+	for _, res := range results {
+		if res.Status == resolver.StatusSuccess {
+			codes = append(codes, res.Code)
+		}
+	}
+
+	// The fetch is shared with concurrent requests, so one client going away must
+	// not cancel it for the others.
+	ctx := context.WithoutCancel(r.Context())
+	value, err := s.cache.get("quote:"+strings.Join(codes, ","), func() (any, error) {
+		fetchRes, err := s.cfg.Stocks.FetchStocks(ctx, results, 0)
+		if err != nil {
+			return nil, err
+		}
+		return fetchRes.Stocks, nil
+	})
+	if err != nil {
+		s.cfg.Logger.Warn("quote request failed: %v", err)
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, output.NewQuoteRecords(results, value.([]models.Stock)))
+}
+
+func (s *Server) handleIndices(w http.ResponseWriter, r *http.Request) {
+	ctx := context.WithoutCancel(r.Context())
+	value, err := s.cache.get("indices", func() (any, error) {
+		return s.cfg.Stocks.FetchIndices(ctx)
+	})
+	if err != nil {
+		s.cfg.Logger.Warn("indices request failed: %v", err)
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, value)
+}
+
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		writeError(w, http.StatusBadRequest, "missing query parameter 'q'")
+		return
+	}
+
+	limit := defaultSearchLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 {
+			writeError(w, http.StatusBadRequest, "invalid limit '"+raw+"'")
+			return
+		}
+		limit = n
+	}
+
+	s.resolveMu.Lock()
+	tickers, err := s.cfg.Stocks.SearchTickers(query)
+	s.resolveMu.Unlock()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if len(tickers) > limit {
+		tickers = tickers[:limit]
+	}
+
+	writeJSON(w, http.StatusOK, append([]models.Ticker{}, tickers...))
+}
+
+func (s *Server) handlePortfolios(w http.ResponseWriter, r *http.Request) {
+	all := s.cfg.Portfolios.ListPortfolios()
+
+	names := make([]string, 0, len(all))
+	for name := range all {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	records := make([]output.PortfolioRecord, 0, len(names))
+	for _, name := range names {
+		for _, h := range all[name] {
+			records = append(records, output.PortfolioRecord{Portfolio: name, Holding: h})
+		}
+	}
+
+	writeJSON(w, http.StatusOK, records)
+}
+
+func (s *Server) handleAliases(w http.ResponseWriter, r *http.Request) {
+	all := s.cfg.Aliases.ListAliases()
+
+	keys := make([]string, 0, len(all))
+	for k := range all {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	records := make([]output.AliasRecord, 0, len(keys))
+	for _, k := range keys {
+		records = append(records, output.AliasRecord{Alias: k, Code: all[k]})
+	}
+
+	writeJSON(w, http.StatusOK, records)
+}
+
+func splitQuery(q string) []string {
+	var inputs []string
+	for _, part := range strings.Split(q, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			inputs = append(inputs, part)
+		}
+	}
+	return inputs
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,186 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ericyhkim/juga/internal/output"
+	"github.com/ericyhkim/juga/pkg/diag"
+	"github.com/ericyhkim/juga/pkg/models"
+	"github.com/ericyhkim/juga/pkg/quote/quotetest"
+	"github.com/ericyhkim/juga/pkg/resolver"
+	"github.com/ericyhkim/juga/pkg/service"
+	"github.com/ericyhkim/juga/pkg/storage"
+)
+
+func newProvider() *quotetest.Provider {
+	return &quotetest.Provider{
+		Stock: func(code string) models.Stock {
+			return models.Stock{Code: code, Name: "name-" + code, Price: 1000}
+		},
+		Indices: []models.Stock{{Code: "KOSPI", Name: "코스피", Price: 2500}},
+	}
+}
+
+func newTestServer(t *testing.T, client *quotetest.Provider) *Server {
+	t.Helper()
+	dir := t.TempDir()
+	logger := diag.NewNopLogger()
+
+	aliases := storage.NewAliasRepository(filepath.Join(dir, "aliases.json"), logger)
+	portfolios := storage.NewPortfolioRepository(filepath.Join(dir, "portfolios.json"), logger)
+	cache := storage.NewCacheRepository(filepath.Join(dir, "cache.json"), 10, logger)
+	tickers := storage.NewTickerRepository(filepath.Join(dir, "master_tickers.csv"), logger)
+
+	if err := aliases.Add("sam", "005930"); err != nil {
+		t.Fatal(err)
+	}
+	if err := portfolios.Add("tech", []models.Holding{{Item: "sam"}, {Item: "000660"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	res := resolver.NewResolver(portfolios, aliases, cache, tickers, logger)
+
+	return New(Config{
+		Resolver:   res,
+		Stocks:     service.NewStockService(tickers, client, logger, time.Second),
+		Aliases:    service.NewAliasService(aliases, res),
+		Portfolios: service.NewPortfolioService(portfolios),
+		Logger:     logger,
+		CacheTTL:   time.Minute,
+	})
+}
+
+func get(t *testing.T, h http.Handler, url string, v any) int {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+	if v != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), v); err != nil {
+			t.Fatalf("GET %s: invalid JSON %q: %v", url, rec.Body.String(), err)
+		}
+	}
+	return rec.Code
+}
+
+func TestQuote_ResolvesAndCaches(t *testing.T) {
+	client := newProvider()
+	h := newTestServer(t, client).Handler()
+
+	var records []output.QuoteRecord
+	if code := get(t, h, "/quote?q=@tech,:sam", &records); code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", code)
+	}
+	if len(records) != 2 || records[0].Code != "005930" || records[1].Code != "000660" {
+		t.Fatalf("Expected portfolio expansion with duplicate dropped, got %+v", records)
+	}
+	if records[0].Stock == nil || records[0].Price != 1000 {
+		t.Errorf("Expected quote attached to record, got %+v", records[0])
+	}
+
+	get(t, h, "/quote?q=@tech", nil)
+	if n := client.Calls(); n != 1 {
+		t.Errorf("Expected the second request to hit the cache, got %d upstream calls", n)
+	}
+
+	get(t, h, "/indices", nil)
+	get(t, h, "/indices", nil)
+	if n := client.Calls(); n != 2 {
+		t.Errorf("Expected one upstream call for indices, got %d total", n-1)
+	}
+}
+
+func TestQuote_MissingQuery(t *testing.T) {
+	h := newTestServer(t, newProvider()).Handler()
+
+	var body map[string]string
+	if code := get(t, h, "/quote", &body); code != http.StatusBadRequest {
+		t.Errorf("Expected 400, got %d", code)
+	}
+	if body["error"] == "" {
+		t.Errorf("Expected an error message, got %v", body)
+	}
+}
+
+func TestSearchAndLocalData(t *testing.T) {
+	h := newTestServer(t, newProvider()).Handler()
+
+	var tickers []models.Ticker
+	if code := get(t, h, "/search?q=삼성전자&limit=1", &tickers); code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", code)
+	}
+	if len(tickers) != 1 || tickers[0].Code != "005930" {
+		t.Errorf("Expected 삼성전자, got %+v", tickers)
+	}
+
+	var aliases []output.AliasRecord
+	get(t, h, "/aliases", &aliases)
+	if len(aliases) != 1 || aliases[0].Alias != "sam" {
+		t.Errorf("Unexpected aliases: %+v", aliases)
+	}
+
+	var portfolios []output.PortfolioRecord
+	get(t, h, "/portfolios", &portfolios)
+	if len(portfolios) != 2 || portfolios[0].Portfolio != "tech" {
+		t.Errorf("Unexpected portfolios: %+v", portfolios)
+	}
+}
+
+func TestSearchAndQuote_Concurrent(t *testing.T) {
+	h := newTestServer(t, newProvider()).Handler()
+
+	// Both handlers load the ticker database on first use; run with -race.
+	var wg sync.WaitGroup
+	for _, url := range []string{"/search?q=삼성", "/quote?q=삼성전자", "/search?q=하이닉스", "/quote?q=:sam"} {
+		wg.Go(func() {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+			if rec.Code != http.StatusOK {
+				t.Errorf("GET %s: expected 200, got %d", url, rec.Code)
+			}
+		})
+	}
+	wg.Wait()
+}
+
+func TestResponseCache_Expires(t *testing.T) {
+	c := newResponseCache(time.Second)
+	now := time.Unix(0, 0)
+	c.now = func() time.Time { return now }
+
+	calls := 0
+	fetch := func() (any, error) {
+		calls++
+		return calls, nil
+	}
+
+	c.get("k", fetch)
+	c.get("k", fetch)
+	now = now.Add(2 * time.Second)
+	v, _ := c.get("k", fetch)
+
+	if calls != 2 || v.(int) != 2 {
+		t.Errorf("Expected a refetch after expiry, got %d calls (value %v)", calls, v)
+	}
+}
+
+func TestResponseCache_DropsExpiredEntries(t *testing.T) {
+	c := newResponseCache(time.Second)
+	now := time.Unix(0, 0)
+	c.now = func() time.Time { return now }
+
+	fetch := func() (any, error) { return 1, nil }
+	c.get("a", fetch)
+	c.get("b", fetch)
+	now = now.Add(2 * time.Second)
+	c.get("c", fetch)
+
+	if len(c.entries) != 1 {
+		t.Errorf("Expected expired entries to be dropped, got %d entries", len(c.entries))
+	}
+}
@@ -0,0 +1,26 @@
+package sys
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// RunShell runs command through the platform shell with extra environment variables,
+// forwarding its output to the current process.
+func RunShell(ctx context.Context, command string, env []string) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = nil
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	return cmd.Run()
+}
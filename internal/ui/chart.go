@@ -0,0 +1,189 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ericyhkim/juga/pkg/models"
+)
+
+const (
+	chartMinHeight = 5
+	chartMinWidth  = 10
+
+	chartBody = "┃"
+	chartWick = "│"
+)
+
+// RenderCandleChart draws a candlestick chart that fits within width columns and
+// height rows. Candles are merged into buckets when there are more of them than
+// columns available. The last price is marked on the right edge and the period's
+// high, low and overall change are summarized below the chart.
+func RenderCandleChart(candles []models.Candle, width, height int) string {
+	if len(candles) == 0 {
+		return StyleNameInactive.Render("No price history found.")
+	}
+	if height < chartMinHeight {
+		height = chartMinHeight
+	}
+
+	maxHigh, minLow := candles[0].High, candles[0].Low
+	highIdx, lowIdx := 0, 0
+	for i, c := range candles {
+		if c.High > maxHigh {
+			maxHigh, highIdx = c.High, i
+		}
+		if c.Low < minLow {
+			minLow, lowIdx = c.Low, i
+		}
+	}
+
+	last := candles[len(candles)-1]
+	first := candles[0]
+
+	axisLabels := map[int]string{
+		0:          formatNumber(maxHigh),
+		height / 2: formatNumber(maxHigh - (maxHigh-minLow)/2),
+		height - 1: formatNumber(minLow),
+	}
+	axisWidth := 0
+	for _, l := range axisLabels {
+		if w := lipgloss.Width(l); w > axisWidth {
+			axisWidth = w
+		}
+	}
+
+	lastLabel := "◀ " + formatNumber(last.Close)
+	plotWidth := width - axisWidth - 2 - lipgloss.Width(lastLabel) - 1
+	if plotWidth < chartMinWidth {
+		plotWidth = chartMinWidth
+	}
+
+	buckets := bucketCandles(candles, plotWidth)
+
+	rowOf := func(price float64) int {
+		if maxHigh == minLow {
+			return height / 2
+		}
+		return int(math.Round((maxHigh - price) / (maxHigh - minLow) * float64(height-1)))
+	}
+
+	lastStyle := StyleChangeNeutral
+	if last.Close > last.Open {
+		lastStyle = StyleChangeRise
+	} else if last.Close < last.Open {
+		lastStyle = StyleChangeFall
+	}
+	lastRow := rowOf(last.Close)
+
+	var rows []string
+	for r := 0; r < height; r++ {
+		var sb strings.Builder
+
+		gutter := " │"
+		if label, ok := axisLabels[r]; ok {
+			gutter = " ┤"
+			sb.WriteString(StyleNameInactive.Copy().Width(axisWidth).Align(lipgloss.Right).Render(label))
+		} else {
+			sb.WriteString(strings.Repeat(" ", axisWidth))
+		}
+		sb.WriteString(StyleNameInactive.Render(gutter))
+
+		for _, c := range buckets {
+			style := StyleChangeRise
+			if c.Close < c.Open {
+				style = StyleChangeFall
+			}
+
+			bodyTop := rowOf(math.Max(c.Open, c.Close))
+			bodyBottom := rowOf(math.Min(c.Open, c.Close))
+
+			switch {
+			case r >= bodyTop && r <= bodyBottom:
+				sb.WriteString(style.Render(chartBody))
+			case r >= rowOf(c.High) && r <= rowOf(c.Low):
+				sb.WriteString(style.Render(chartWick))
+			default:
+				sb.WriteString(" ")
+			}
+		}
+
+		if r == lastRow {
+			sb.WriteString(" " + lastStyle.Render(lastLabel))
+		}
+
+		rows = append(rows, strings.TrimRight(sb.String(), " "))
+	}
+
+	firstDate := first.Date.Format("2006-01-02")
+	lastDate := last.Date.Format("2006-01-02")
+	gap := len(buckets) - len(firstDate) - len(lastDate)
+	if gap < 1 {
+		gap = 1
+	}
+	rows = append(rows, strings.Repeat(" ", axisWidth+2)+StyleNameInactive.Render(firstDate+strings.Repeat(" ", gap)+lastDate))
+
+	change := last.Close - first.Close
+	changePercent := 0.0
+	if first.Close != 0 {
+		changePercent = change / first.Close * 100
+	}
+	changeStyle := StyleChangeNeutral
+	symbol := "-"
+	if change > 0 {
+		changeStyle, symbol = StyleChangeRise, "▲"
+	} else if change < 0 {
+		changeStyle, symbol = StyleChangeFall, "▼"
+	}
+
+	summary := fmt.Sprintf("%s %s   %s %s   %s %s %s",
+		StyleNameInactive.Render("High"),
+		StyleChangeRise.Render(fmt.Sprintf("%s (%s)", formatNumber(maxHigh), candles[highIdx].Date.Format("01-02"))),
+		StyleNameInactive.Render("Low"),
+		StyleChangeFall.Render(fmt.Sprintf("%s (%s)", formatNumber(minLow), candles[lowIdx].Date.Format("01-02"))),
+		StyleNameInactive.Render("Last"),
+		StylePrice.Render(formatNumber(last.Close)),
+		changeStyle.Render(fmt.Sprintf("%s %s (%.2f%%)", symbol, formatNumber(change), changePercent)),
+	)
+	rows = append(rows, "", strings.Repeat(" ", axisWidth+2)+summary)
+
+	return strings.Join(rows, "\n")
+}
+
+// bucketCandles merges consecutive candles so that at most n remain.
+func bucketCandles(candles []models.Candle, n int) []models.Candle {
+	if len(candles) <= n {
+		return candles
+	}
+
+	buckets := make([]models.Candle, 0, n)
+	size := float64(len(candles)) / float64(n)
+	for i := 0; i < n; i++ {
+		start := int(float64(i) * size)
+		end := int(float64(i+1) * size)
+		if end > len(candles) {
+			end = len(candles)
+		}
+		if start >= end {
+			continue
+		}
+
+		group := candles[start:end]
+		merged := models.Candle{
+			Date:  group[len(group)-1].Date,
+			Open:  group[0].Open,
+			Close: group[len(group)-1].Close,
+			High:  group[0].High,
+			Low:   group[0].Low,
+		}
+		for _, c := range group {
+			merged.High = math.Max(merged.High, c.High)
+			merged.Low = math.Min(merged.Low, c.Low)
+			merged.Volume += c.Volume
+		}
+		buckets = append(buckets, merged)
+	}
+	return buckets
+}
@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ericyhkim/juga/pkg/models"
+)
+
+func sampleCandles(n int) []models.Candle {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+	candles := make([]models.Candle, 0, n)
+	for i := 0; i < n; i++ {
+		base := 70000 + float64(i%10)*1000
+		candles = append(candles, models.Candle{
+			Date:   start.AddDate(0, 0, i),
+			Open:   base,
+			High:   base + 1500,
+			Low:    base - 500,
+			Close:  base + 500,
+			Volume: 1000,
+		})
+	}
+	return candles
+}
+
+func TestBucketCandles(t *testing.T) {
+	candles := sampleCandles(100)
+
+	buckets := bucketCandles(candles, 30)
+	if len(buckets) != 30 {
+		t.Fatalf("Expected 30 buckets, got %d", len(buckets))
+	}
+
+	var volume float64
+	for _, b := range buckets {
+		volume += b.Volume
+	}
+	if volume != 100*1000 {
+		t.Errorf("Expected total volume to be preserved, got %f", volume)
+	}
+
+	if got := bucketCandles(candles[:10], 30); len(got) != 10 {
+		t.Errorf("Expected short series to be returned as-is, got %d", len(got))
+	}
+}
+
+func TestRenderCandleChart(t *testing.T) {
+	candles := sampleCandles(40)
+
+	result := RenderCandleChart(candles, 80, 10)
+
+	for _, line := range strings.Split(result, "\n") {
+		if w := len([]rune(stripANSI(line))); w > 80 {
+			t.Errorf("Line exceeds width 80 (%d): %q", w, line)
+		}
+	}
+	if !strings.Contains(result, "80,500") {
+		t.Errorf("Expected high 80,500 to be annotated: %s", result)
+	}
+	if !strings.Contains(result, "69,500") {
+		t.Errorf("Expected low 69,500 to be annotated: %s", result)
+	}
+	if !strings.Contains(result, "◀ 79,500") {
+		t.Errorf("Expected last price marker: %s", result)
+	}
+}
+
+func stripANSI(s string) string {
+	var sb strings.Builder
+	inEscape := false
+	for _, r := range s {
+		switch {
+		case r == '\x1b':
+			inEscape = true
+		case inEscape && (r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'):
+			inEscape = false
+		case !inEscape:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,505 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ericyhkim/juga/pkg/models"
+)
+
+const (
+	dashSearchLimit = 10
+	// dashChromeLines is the number of rows used around the stock list
+	// (indices, tabs, two separators, two detail lines and the help line).
+	dashChromeLines = 7
+)
+
+// DashboardSource provides the data shown by the dashboard.
+type DashboardSource interface {
+	Portfolios() []string
+	FetchPortfolio(name string) ([]models.Stock, error)
+	FetchIndices() ([]models.Stock, error)
+	SearchTickers(query string) []models.Ticker
+	AddToPortfolio(name, code string) error
+}
+
+type dashSortColumn int
+
+const (
+	sortNone dashSortColumn = iota
+	sortName
+	sortPrice
+	sortChange
+	sortValue
+	sortColumnCount
+)
+
+func (c dashSortColumn) String() string {
+	switch c {
+	case sortName:
+		return "name"
+	case sortPrice:
+		return "price"
+	case sortChange:
+		return "change"
+	case sortValue:
+		return "value"
+	default:
+		return "portfolio order"
+	}
+}
+
+type dashQuotesMsg struct {
+	portfolio string
+	stocks    []models.Stock
+	err       error
+}
+
+type dashIndicesMsg struct {
+	indices []models.Stock
+	err     error
+}
+
+type dashSearchMsg struct {
+	query   string
+	results []models.Ticker
+}
+
+type dashAddedMsg struct {
+	portfolio string
+	name      string
+	err       error
+}
+
+type dashTickMsg struct{}
+
+type dashboardModel struct {
+	src      DashboardSource
+	interval time.Duration
+
+	portfolios []string
+	current    int
+
+	stocks  []models.Stock
+	indices []models.Stock
+	err     error
+	updated time.Time
+
+	cursor   int
+	offset   int
+	sortCol  dashSortColumn
+	sortDesc bool
+
+	searching    bool
+	input        textinput.Model
+	results      []models.Ticker
+	resultCursor int
+	notice       string
+
+	width  int
+	height int
+}
+
+// RunDashboard starts the full-screen dashboard on the given portfolio and blocks until the user quits.
+// If defaultPortfolio does not exist yet, it is shown empty and created when a stock is added.
+func RunDashboard(src DashboardSource, defaultPortfolio string, interval time.Duration) error {
+	_, err := tea.NewProgram(newDashboardModel(src, defaultPortfolio, interval), tea.WithAltScreen()).Run()
+	return err
+}
+
+func newDashboardModel(src DashboardSource, defaultPortfolio string, interval time.Duration) *dashboardModel {
+	input := textinput.New()
+	input.Placeholder = "Search stocks to add..."
+	input.Prompt = "/ "
+
+	m := &dashboardModel{
+		src:      src,
+		interval: interval,
+		input:    input,
+	}
+	m.loadPortfolios(defaultPortfolio)
+	return m
+}
+
+func (m *dashboardModel) loadPortfolios(selected string) {
+	m.portfolios = m.src.Portfolios()
+	m.current = -1
+	for i, p := range m.portfolios {
+		if p == selected {
+			m.current = i
+		}
+	}
+	if m.current == -1 {
+		m.portfolios = append(m.portfolios, selected)
+		sort.Strings(m.portfolios)
+		for i, p := range m.portfolios {
+			if p == selected {
+				m.current = i
+			}
+		}
+	}
+}
+
+func (m *dashboardModel) portfolio() string {
+	return m.portfolios[m.current]
+}
+
+func (m *dashboardModel) Init() tea.Cmd {
+	return tea.Batch(m.fetchQuotes(), m.fetchIndices(), m.tick())
+}
+
+func (m *dashboardModel) fetchQuotes() tea.Cmd {
+	name := m.portfolio()
+	return func() tea.Msg {
+		stocks, err := m.src.FetchPortfolio(name)
+		return dashQuotesMsg{portfolio: name, stocks: stocks, err: err}
+	}
+}
+
+func (m *dashboardModel) fetchIndices() tea.Cmd {
+	return func() tea.Msg {
+		indices, err := m.src.FetchIndices()
+		return dashIndicesMsg{indices: indices, err: err}
+	}
+}
+
+func (m *dashboardModel) tick() tea.Cmd {
+	return tea.Tick(m.interval, func(time.Time) tea.Msg {
+		return dashTickMsg{}
+	})
+}
+
+func (m *dashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.clampCursor()
+		return m, nil
+
+	case dashTickMsg:
+		return m, tea.Batch(m.fetchQuotes(), m.fetchIndices(), m.tick())
+
+	case dashQuotesMsg:
+		if msg.portfolio != m.portfolio() {
+			return m, nil
+		}
+		m.err = msg.err
+		if msg.err == nil {
+			m.stocks = msg.stocks
+			m.updated = time.Now()
+			m.sortStocks()
+			m.clampCursor()
+		}
+		return m, nil
+
+	case dashIndicesMsg:
+		if msg.err == nil {
+			m.indices = msg.indices
+		}
+		return m, nil
+
+	case dashSearchMsg:
+		// Results of an older query may arrive after those of the current one.
+		if !m.searching || msg.query != m.input.Value() {
+			return m, nil
+		}
+		m.results = msg.results
+		if m.resultCursor >= len(m.results) {
+			m.resultCursor = 0
+		}
+		return m, nil
+
+	case dashAddedMsg:
+		if msg.err != nil {
+			m.notice = fmt.Sprintf("Failed to add %s: %v", msg.name, msg.err)
+			return m, nil
+		}
+		m.notice = fmt.Sprintf("Added %s to '%s'", msg.name, msg.portfolio)
+		m.loadPortfolios(msg.portfolio)
+		return m, m.fetchQuotes()
+
+	case tea.KeyMsg:
+		if m.searching {
+			return m.updateSearch(msg)
+		}
+		return m.updateList(msg)
+	}
+
+	return m, nil
+}
+
+func (m *dashboardModel) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "ctrl+c", "esc":
+		return m, tea.Quit
+	case "up", "k":
+		m.cursor--
+	case "down", "j":
+		m.cursor++
+	case "pgup":
+		m.cursor -= m.listHeight()
+	case "pgdown":
+		m.cursor += m.listHeight()
+	case "home", "g":
+		m.cursor = 0
+	case "end", "G":
+		m.cursor = len(m.stocks) - 1
+	case "left", "h", "shift+tab", "[":
+		return m, m.switchPortfolio(-1)
+	case "right", "l", "tab", "]":
+		return m, m.switchPortfolio(1)
+	case "s":
+		m.sortCol = (m.sortCol + 1) % sortColumnCount
+		m.sortStocks()
+	case "S":
+		m.sortDesc = !m.sortDesc
+		m.sortStocks()
+	case "r":
+		return m, tea.Batch(m.fetchQuotes(), m.fetchIndices())
+	case "/", "a":
+		m.searching = true
+		m.notice = ""
+		m.results = nil
+		m.resultCursor = 0
+		m.input.Reset()
+		return m, m.input.Focus()
+	}
+
+	m.clampCursor()
+	return m, nil
+}
+
+func (m *dashboardModel) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.searching = false
+		m.input.Blur()
+		return m, nil
+	case "up", "ctrl+p":
+		if m.resultCursor > 0 {
+			m.resultCursor--
+		}
+		return m, nil
+	case "down", "ctrl+n":
+		if m.resultCursor < len(m.results)-1 {
+			m.resultCursor++
+		}
+		return m, nil
+	case "enter":
+		m.searching = false
+		m.input.Blur()
+		if len(m.results) == 0 {
+			return m, nil
+		}
+		picked := m.results[m.resultCursor]
+		portfolio := m.portfolio()
+		return m, func() tea.Msg {
+			err := m.src.AddToPortfolio(portfolio, picked.Code)
+			return dashAddedMsg{portfolio: portfolio, name: picked.Name, err: err}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+
+	return m, tea.Batch(cmd, m.search(m.input.Value()))
+}
+
+func (m *dashboardModel) search(query string) tea.Cmd {
+	return func() tea.Msg {
+		results := m.src.SearchTickers(query)
+		if len(results) > dashSearchLimit {
+			results = results[:dashSearchLimit]
+		}
+		return dashSearchMsg{query: query, results: results}
+	}
+}
+
+func (m *dashboardModel) switchPortfolio(delta int) tea.Cmd {
+	if len(m.portfolios) < 2 {
+		return nil
+	}
+	m.current = (m.current + delta + len(m.portfolios)) % len(m.portfolios)
+	m.stocks = nil
+	m.err = nil
+	m.cursor, m.offset = 0, 0
+	return m.fetchQuotes()
+}
+
+func (m *dashboardModel) sortStocks() {
+	if m.sortCol == sortNone {
+		return
+	}
+
+	less := func(a, b models.Stock) bool {
+		switch m.sortCol {
+		case sortName:
+			return a.Name < b.Name
+		case sortPrice:
+			return a.Price < b.Price
+		case sortChange:
+			return a.ChangePercent < b.ChangePercent
+		default:
+			return a.TradingValue < b.TradingValue
+		}
+	}
+
+	sort.SliceStable(m.stocks, func(i, j int) bool {
+		if m.sortDesc {
+			return less(m.stocks[j], m.stocks[i])
+		}
+		return less(m.stocks[i], m.stocks[j])
+	})
+}
+
+func (m *dashboardModel) listHeight() int {
+	h := m.height - dashChromeLines
+	if h < 1 {
+		return 1
+	}
+	return h
+}
+
+func (m *dashboardModel) clampCursor() {
+	if m.cursor >= len(m.stocks) {
+		m.cursor = len(m.stocks) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if h := m.listHeight(); m.cursor >= m.offset+h {
+		m.offset = m.cursor - h + 1
+	}
+}
+
+func (m *dashboardModel) View() string {
+	presenter := NewPresenter()
+	separator := StyleNameInactive.Render(strings.Repeat("─", max(m.width, 1)))
+
+	var sections []string
+
+	sections = append(sections, RenderIndices(presenter.PrepareList(m.indices)))
+	sections = append(sections, m.viewTabs(), separator)
+
+	if m.searching {
+		sections = append(sections, m.viewSearch())
+	} else {
+		sections = append(sections, m.viewList(presenter), separator, m.viewDetail(presenter))
+	}
+
+	sections = append(sections, m.viewHelp())
+
+	return strings.Join(sections, "\n")
+}
+
+func (m *dashboardModel) viewTabs() string {
+	tabs := make([]string, 0, len(m.portfolios))
+	for i, p := range m.portfolios {
+		if i == m.current {
+			tabs = append(tabs, StyleNameActive.Copy().Underline(true).Render("@"+p))
+		} else {
+			tabs = append(tabs, StyleNameInactive.Render("@"+p))
+		}
+	}
+
+	direction := "▲"
+	if m.sortDesc {
+		direction = "▼"
+	}
+	sortInfo := StyleNameInactive.Render(fmt.Sprintf("  sort: %s %s", m.sortCol, direction))
+
+	return strings.Join(tabs, "  ") + sortInfo
+}
+
+func (m *dashboardModel) viewList(presenter *Presenter) string {
+	height := m.listHeight()
+
+	if m.err != nil {
+		return padLines(StyleHelpError.Render(fmt.Sprintf("Error fetching data: %v", m.err)), height)
+	}
+	if len(m.stocks) == 0 {
+		return padLines(StyleNameInactive.Render("No stocks in this portfolio. Press / to add one."), height)
+	}
+
+	lines := strings.Split(RenderStockTable(presenter.PrepareList(m.stocks)), "\n")
+
+	end := min(m.offset+height, len(lines))
+	visible := make([]string, 0, height)
+	for i := m.offset; i < end; i++ {
+		marker := "  "
+		if i == m.cursor {
+			marker = StyleNameActive.Render("› ")
+		}
+		visible = append(visible, marker+lines[i])
+	}
+
+	return padLines(strings.Join(visible, "\n"), height)
+}
+
+func (m *dashboardModel) viewDetail(presenter *Presenter) string {
+	if m.cursor >= len(m.stocks) {
+		return ""
+	}
+
+	s := m.stocks[m.cursor]
+	vm := presenter.PrepareStock(s)
+
+	title := GetStyle(vm.NameStyle).Render(fmt.Sprintf("%s (%s)", vm.Name, s.Code))
+	stats := GetStyle(vm.ChangeStyle).Render(fmt.Sprintf("%s %s", vm.Price, vm.ChangeInfo))
+	details := StyleNameInactive.Render(fmt.Sprintf("High: %s   Low: %s   Val: %s   Status: %s",
+		vm.High, vm.Low, vm.TradingValue, s.MarketStatus))
+
+	return title + "  " + stats + "\n" + details
+}
+
+func (m *dashboardModel) viewSearch() string {
+	lines := []string{m.input.View(), ""}
+	for i, t := range m.results {
+		line := fmt.Sprintf("%s  %s", StyleNameActive.Copy().Width(20).Render(t.Name), StyleNameInactive.Render(fmt.Sprintf("%s [%s]", t.Code, t.Market)))
+		if i == m.resultCursor {
+			line = StyleNameActive.Render("› ") + line
+		} else {
+			line = "  " + line
+		}
+		lines = append(lines, line)
+	}
+	return padLines(strings.Join(lines, "\n"), m.listHeight()+3)
+}
+
+func (m *dashboardModel) viewHelp() string {
+	var parts []string
+	if m.notice != "" {
+		parts = append(parts, m.notice)
+	}
+	if !m.updated.IsZero() {
+		parts = append(parts, "Updated "+m.updated.Format("15:04:05"))
+	}
+
+	if m.searching {
+		parts = append(parts, "↑/↓ select · enter add · esc cancel")
+	} else {
+		parts = append(parts, "←/→ portfolio · ↑/↓ move · s sort · S reverse · / add · r refresh · q quit")
+	}
+
+	return StyleNameInactive.Render(strings.Join(parts, " · "))
+}
+
+// padLines pads s with empty lines so that it occupies exactly n rows.
+func padLines(s string, n int) string {
+	lines := strings.Split(s, "\n")
+	if len(lines) > n {
+		lines = lines[:n]
+	}
+	for len(lines) < n {
+		lines = append(lines, "")
+	}
+	return strings.Join(lines, "\n")
+}
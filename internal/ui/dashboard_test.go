@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ericyhkim/juga/pkg/models"
+)
+
+type fakeDashboardSource struct {
+	portfolios map[string][]models.Stock
+	added      []string
+}
+
+func (f *fakeDashboardSource) Portfolios() []string {
+	var names []string
+	for name := range f.portfolios {
+		names = append(names, name)
+	}
+	return names
+}
+
+func (f *fakeDashboardSource) FetchPortfolio(name string) ([]models.Stock, error) {
+	return f.portfolios[name], nil
+}
+
+func (f *fakeDashboardSource) FetchIndices() ([]models.Stock, error) {
+	return nil, nil
+}
+
+func (f *fakeDashboardSource) SearchTickers(query string) []models.Ticker {
+	return []models.Ticker{{Code: "035720", Name: "카카오", Market: "KOSPI"}}
+}
+
+func (f *fakeDashboardSource) AddToPortfolio(name, code string) error {
+	f.added = append(f.added, name+":"+code)
+	return nil
+}
+
+func newTestDashboard(src *fakeDashboardSource) *dashboardModel {
+	m := newDashboardModel(src, "tech", time.Second)
+	m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	return m
+}
+
+// send updates m with msg and the messages of the commands it returns, in order.
+func send(m *dashboardModel, msg tea.Msg) {
+	_, cmd := m.Update(msg)
+	run(m, cmd)
+}
+
+func run(m *dashboardModel, cmd tea.Cmd) {
+	if cmd == nil {
+		return
+	}
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		for _, c := range msg {
+			run(m, c)
+		}
+	case dashSearchMsg:
+		send(m, msg)
+	}
+}
+
+func TestDashboard_SortAndSelect(t *testing.T) {
+	src := &fakeDashboardSource{portfolios: map[string][]models.Stock{
+		"tech": {
+			{Code: "005930", Name: "Samsung", Price: 75000, ChangePercent: 1.2},
+			{Code: "000660", Name: "Hynix", Price: 130000, ChangePercent: -0.5},
+		},
+	}}
+	m := newTestDashboard(src)
+
+	stocks, _ := src.FetchPortfolio("tech")
+	m.Update(dashQuotesMsg{portfolio: "tech", stocks: stocks})
+
+	// sortNone -> sortName
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	if m.stocks[0].Name != "Hynix" {
+		t.Errorf("Expected name sort to put Hynix first, got %s", m.stocks[0].Name)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if !strings.Contains(m.View(), "Samsung (005930)") {
+		t.Errorf("Expected detail pane to show the selected stock:\n%s", m.View())
+	}
+}
+
+func TestDashboard_SearchAndAdd(t *testing.T) {
+	src := &fakeDashboardSource{portfolios: map[string][]models.Stock{}}
+	m := newTestDashboard(src)
+
+	if len(m.portfolios) != 1 || m.portfolio() != "tech" {
+		t.Fatalf("Expected missing portfolio to be shown, got %v", m.portfolios)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("ㅋ")})
+	if len(m.results) != 1 {
+		t.Fatalf("Expected search results, got %v", m.results)
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected a command adding the stock")
+	}
+	cmd()
+
+	if len(src.added) != 1 || src.added[0] != "tech:035720" {
+		t.Errorf("Expected stock to be added to 'tech', got %v", src.added)
+	}
+}
+
+func TestDashboard_IgnoresStaleSearchResults(t *testing.T) {
+	src := &fakeDashboardSource{portfolios: map[string][]models.Stock{}}
+	m := newTestDashboard(src)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	_, slow := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+
+	// The search for "a" completes after the query became "ab".
+	run(m, slow)
+	if len(m.results) != 0 {
+		t.Errorf("Expected results of an outdated query to be dropped, got %v", m.results)
+	}
+}
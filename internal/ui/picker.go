@@ -17,36 +17,71 @@ func RunPicker(title string, items []ListItem) (string, error) {
 		return "", fmt.Errorf("no items to pick from")
 	}
 
-	options := make([]huh.Option[string], 0, len(items))
-	for _, item := range items {
-		options = append(options, huh.NewOption(
-			fmt.Sprintf("%s (%s)", item.Key, item.Value),
-			item.Value,
-		))
+	var selected string
+	form := huh.NewForm(
+		huh.NewGroup(pickerSelect(title, items, &selected)),
+	)
+	form.WithTheme(pickerTheme())
+
+	if err := form.Run(); err != nil {
+		return "", err
+	}
+
+	return selected, nil
+}
+
+// RunPickerWithConfirm displays a selection list followed by a yes/no question about
+// the selection, such as whether to remember it. Without a terminal the first item
+// is returned and the question is answered no.
+func RunPickerWithConfirm(title string, items []ListItem, question string) (string, bool, error) {
+	if !isatty.IsTerminal(os.Stdin.Fd()) && !isatty.IsCygwinTerminal(os.Stdin.Fd()) {
+		selected, err := RunPicker(title, items)
+		return selected, false, err
 	}
 
 	var selected string
+	var confirmed bool
 	form := huh.NewForm(
 		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title(title).
-				Options(options...).
-				Inline(true).
-				Value(&selected),
+			pickerSelect(title, items, &selected),
+			huh.NewConfirm().
+				Title(question).
+				Affirmative("Yes").
+				Negative("Just this time").
+				Value(&confirmed),
 		),
 	)
+	form.WithTheme(pickerTheme())
+
+	if err := form.Run(); err != nil {
+		return "", false, err
+	}
+
+	return selected, confirmed, nil
+}
+
+func pickerSelect(title string, items []ListItem, selected *string) *huh.Select[string] {
+	options := make([]huh.Option[string], 0, len(items))
+	for _, item := range items {
+		label := item.Value
+		if item.Key != "" {
+			label = fmt.Sprintf("%s (%s)", item.Key, item.Value)
+		}
+		options = append(options, huh.NewOption(label, item.Value))
+	}
 
+	return huh.NewSelect[string]().
+		Title(title).
+		Options(options...).
+		Inline(true).
+		Value(selected)
+}
+
+func pickerTheme() *huh.Theme {
 	theme := huh.ThemeCharm()
 	theme.Focused.Title = StyleNameActive.Copy()
 	theme.Focused.SelectedOption = StylePrice.Copy()
 	theme.Focused.UnselectedOption = StyleNameInactive.Copy()
 	theme.Blurred.Title = StyleNameInactive.Copy()
-	
-	form.WithTheme(theme)
-
-	if err := form.Run(); err != nil {
-		return "", err
-	}
-
-	return selected, nil
+	return theme
 }
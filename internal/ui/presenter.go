@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/ericyhkim/juga/pkg/models"
@@ -23,7 +24,7 @@ func (p *Presenter) PrepareList(stocks []models.Stock) []StockViewModel {
 
 func (p *Presenter) PrepareStock(s models.Stock) StockViewModel {
 	nameStyle := StyleInactive
-	if isMarketOpen(s.MarketStatus) {
+	if s.IsMarketOpen() {
 		nameStyle = StyleActive
 	}
 
@@ -52,11 +53,176 @@ func (p *Presenter) PrepareStock(s models.Stock) StockViewModel {
 	}
 }
 
-// isMarketOpen checks if the market status indicates active trading.
-// This is a simplified check; Naver returns various strings like "OPEN", "CLOSE", "DELAY".
-func isMarketOpen(status string) bool {
-	status = strings.ToUpper(status)
-	return status == "OPEN" || status == "장중" // "장중" is Korean for "During Market"
+// PrepareWatchList formats stocks like PrepareList and highlights prices that moved
+// since the previous tick. previous maps stock codes to their last seen price.
+func (p *Presenter) PrepareWatchList(stocks []models.Stock, previous map[string]float64) []StockViewModel {
+	vms := p.PrepareList(stocks)
+	for i, s := range stocks {
+		last, ok := previous[s.Code]
+		if !ok || last == s.Price {
+			continue
+		}
+		vms[i].Highlight = true
+		vms[i].TickStyle = StyleFall
+		if s.Price > last {
+			vms[i].TickStyle = StyleRise
+		}
+	}
+	return vms
+}
+
+// PrepareCandles formats a price history. Each row's change is measured against the
+// previous close; the first row falls back to its own open.
+func (p *Presenter) PrepareCandles(candles []models.Candle) []CandleViewModel {
+	var vms []CandleViewModel
+	for i, c := range candles {
+		base := c.Open
+		if i > 0 {
+			base = candles[i-1].Close
+		}
+
+		change := c.Close - base
+		changePercent := 0.0
+		if base != 0 {
+			changePercent = change / base * 100
+		}
+
+		changeStyle := StyleNeutral
+		symbol := "-"
+		if change > 0 {
+			changeStyle = StyleRise
+			symbol = "▲"
+		} else if change < 0 {
+			changeStyle = StyleFall
+			symbol = "▼"
+		}
+
+		vms = append(vms, CandleViewModel{
+			Date:        c.Date.Format("2006-01-02"),
+			Open:        formatNumber(c.Open),
+			High:        formatNumber(c.High),
+			Low:         formatNumber(c.Low),
+			Close:       formatNumber(c.Close),
+			Volume:      formatNumber(c.Volume),
+			ChangeInfo:  fmt.Sprintf("%s %s (%.2f%%)", symbol, formatNumber(change), changePercent),
+			ChangeStyle: changeStyle,
+		})
+	}
+	return vms
+}
+
+// PreparePositions formats portfolio positions and computes the totals footer
+// over the rows that carry an actual position.
+func (p *Presenter) PreparePositions(positions []models.Position) ([]PositionViewModel, PositionTotalsViewModel) {
+	var (
+		vms               []PositionViewModel
+		totalCost, totalV float64
+	)
+
+	for _, pos := range positions {
+		vm := PositionViewModel{StockViewModel: p.PrepareStock(pos.Stock)}
+		if pos.HasPosition() {
+			vm.Quantity = formatNumber(pos.Quantity)
+			vm.AvgPrice = formatNumber(pos.AvgPrice)
+			vm.MarketValue = formatNumber(pos.MarketValue())
+			vm.PnL = formatNumber(pos.PnL())
+			vm.PnLPercent = fmt.Sprintf("%.2f%%", pos.PnLPercent())
+			vm.PnLStyle = pnlStyle(pos.PnL())
+
+			totalCost += pos.Cost()
+			totalV += pos.MarketValue()
+		}
+		vms = append(vms, vm)
+	}
+
+	totalPnL := totalV - totalCost
+	totalPnLPercent := 0.0
+	if totalCost != 0 {
+		totalPnLPercent = totalPnL / totalCost * 100
+	}
+
+	totals := PositionTotalsViewModel{
+		Cost:        formatNumber(totalCost),
+		MarketValue: formatNumber(totalV),
+		PnL:         formatNumber(totalPnL),
+		PnLPercent:  fmt.Sprintf("%.2f%%", totalPnLPercent),
+		PnLStyle:    pnlStyle(totalPnL),
+	}
+
+	return vms, totals
+}
+
+// PrepareLedger formats transactions as list rows keyed by date and type.
+func (p *Presenter) PrepareLedger(txs []models.Transaction) []ListItem {
+	items := make([]ListItem, 0, len(txs))
+	for _, tx := range txs {
+		label := tx.Code
+		if tx.Name != "" {
+			label = fmt.Sprintf("%s (%s)", tx.Name, tx.Code)
+		}
+
+		value := fmt.Sprintf("%s  %s @ %s", label, formatNumber(tx.Quantity), formatNumber(tx.Price))
+		if tx.Fee != 0 {
+			value += fmt.Sprintf("  fee %s", formatNumber(tx.Fee))
+		}
+		if tx.Tax != 0 {
+			value += fmt.Sprintf("  tax %s", formatNumber(tx.Tax))
+		}
+
+		items = append(items, ListItem{
+			Key:   fmt.Sprintf("%s %-8s", tx.Date.Format("2006-01-02"), tx.Type),
+			Value: value,
+		})
+	}
+	return items
+}
+
+// PrepareLedgerReport formats a ledger report as one row per stock plus a totals row.
+func (p *Presenter) PrepareLedgerReport(report *models.LedgerReport) []ListItem {
+	items := make([]ListItem, 0, len(report.Positions)+1)
+	for _, pos := range report.Positions {
+		name := pos.Name
+		if name == "" {
+			name = pos.Item
+		}
+
+		held := "closed"
+		if pos.Quantity != 0 {
+			held = fmt.Sprintf("%s @ %s", formatNumber(pos.Quantity), formatNumber(math.Round(pos.AvgPrice*100)/100))
+		}
+
+		items = append(items, ListItem{
+			Key: name,
+			Value: fmt.Sprintf("held %s  realized %s  dividends %s  fees %s  tax %s",
+				held,
+				formatNumber(math.Round(pos.RealizedGain)),
+				formatNumber(pos.DividendIncome),
+				formatNumber(pos.Fees),
+				formatNumber(pos.Taxes),
+			),
+		})
+	}
+
+	items = append(items, ListItem{
+		Key: "Total",
+		Value: fmt.Sprintf("realized %s  dividends %s  fees %s  tax %s",
+			formatNumber(math.Round(report.RealizedGain)),
+			formatNumber(report.DividendIncome),
+			formatNumber(report.Fees),
+			formatNumber(report.Taxes),
+		),
+	})
+	return items
+}
+
+func pnlStyle(pnl float64) StyleType {
+	if pnl > 0 {
+		return StyleRise
+	}
+	if pnl < 0 {
+		return StyleFall
+	}
+	return StyleNeutral
 }
 
 func formatNumber(n float64) string {
@@ -105,4 +271,49 @@ func getDirectionSymbol(s models.Stock) string {
 		return "▼"
 	}
 	return "-"
-}
\ No newline at end of file
+}
+
+// PrepareAlerts formats alerts as list rows keyed by their ID.
+func (p *Presenter) PrepareAlerts(alerts []models.Alert) []ListItem {
+	items := make([]ListItem, 0, len(alerts))
+	for _, a := range alerts {
+		value := fmt.Sprintf("%s  %s", alertLabel(a), DescribeAlertCondition(a))
+		if a.Triggered {
+			value += "  (triggered " + a.LastFired.Format("2006-01-02 15:04") + ")"
+		}
+		if a.Command != "" {
+			value += "  → " + a.Command
+		}
+		items = append(items, ListItem{Key: fmt.Sprintf("#%d", a.ID), Value: value})
+	}
+	return items
+}
+
+// FormatAlertEvent renders a one-line notification for a fired alert, e.g.
+// "삼성전자 (005930) 80,100 ▲1.20% [above 80,000]".
+func FormatAlertEvent(ev models.AlertEvent) string {
+	return fmt.Sprintf("%s %s %s%.2f%% [%s]",
+		alertLabel(ev.Alert),
+		formatNumber(ev.Stock.Price),
+		getDirectionSymbol(ev.Stock),
+		ev.Stock.ChangePercent,
+		DescribeAlertCondition(ev.Alert),
+	)
+}
+
+// DescribeAlertCondition renders an alert's condition, e.g. "above 80,000".
+func DescribeAlertCondition(a models.Alert) string {
+	switch a.Condition {
+	case models.AlertChangePct:
+		return fmt.Sprintf("change ±%s%%", formatNumber(a.Threshold))
+	default:
+		return fmt.Sprintf("%s %s", a.Condition, formatNumber(a.Threshold))
+	}
+}
+
+func alertLabel(a models.Alert) string {
+	if a.Name == "" {
+		return a.Code
+	}
+	return fmt.Sprintf("%s (%s)", a.Name, a.Code)
+}
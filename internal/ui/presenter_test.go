@@ -53,4 +53,83 @@ func TestPresenter_PrepareStock(t *testing.T) {
 	if result.TradingValue != expected.TradingValue {
 		t.Errorf("Expected TradingValue %q, got %q", expected.TradingValue, result.TradingValue)
 	}
-}
\ No newline at end of file
+}
+
+func TestPresenter_PreparePositions(t *testing.T) {
+	p := NewPresenter()
+
+	positions := []models.Position{
+		{Stock: models.Stock{Name: "Samsung", Price: 80000}, Quantity: 10, AvgPrice: 70000},
+		{Stock: models.Stock{Name: "Kakao", Price: 40000}, Quantity: 5, AvgPrice: 50000},
+		{Stock: models.Stock{Name: "Naver", Price: 200000}},
+	}
+
+	rows, totals := p.PreparePositions(positions)
+
+	if len(rows) != 3 {
+		t.Fatalf("Expected 3 rows, got %d", len(rows))
+	}
+	if rows[0].PnL != "100,000" || rows[0].PnLStyle != StyleRise {
+		t.Errorf("Expected Samsung P&L 100,000 rising, got %q (%v)", rows[0].PnL, rows[0].PnLStyle)
+	}
+	if rows[1].PnLPercent != "-20.00%" || rows[1].PnLStyle != StyleFall {
+		t.Errorf("Expected Kakao P&L%% -20.00%% falling, got %q (%v)", rows[1].PnLPercent, rows[1].PnLStyle)
+	}
+	if rows[2].Quantity != "" || rows[2].PnL != "" {
+		t.Errorf("Expected watch-only row to have empty position fields, got %+v", rows[2])
+	}
+
+	// Cost 950,000 -> Value 1,000,000
+	if totals.MarketValue != "1,000,000" {
+		t.Errorf("Expected total value 1,000,000, got %q", totals.MarketValue)
+	}
+	if totals.PnL != "50,000" {
+		t.Errorf("Expected total P&L 50,000, got %q", totals.PnL)
+	}
+	if totals.PnLPercent != "5.26%" {
+		t.Errorf("Expected total P&L%% 5.26%%, got %q", totals.PnLPercent)
+	}
+}
+
+func TestPresenter_PrepareWatchList(t *testing.T) {
+	p := NewPresenter()
+
+	stocks := []models.Stock{
+		{Code: "005930", Name: "Samsung", Price: 75100},
+		{Code: "000660", Name: "SK Hynix", Price: 129000},
+		{Code: "035720", Name: "Kakao", Price: 40000},
+	}
+	previous := map[string]float64{
+		"005930": 75000,
+		"000660": 130000,
+	}
+
+	vms := p.PrepareWatchList(stocks, previous)
+
+	if !vms[0].Highlight || vms[0].TickStyle != StyleRise {
+		t.Errorf("Expected Samsung to be highlighted as rising, got %+v", vms[0])
+	}
+	if !vms[1].Highlight || vms[1].TickStyle != StyleFall {
+		t.Errorf("Expected SK Hynix to be highlighted as falling, got %+v", vms[1])
+	}
+	if vms[2].Highlight {
+		t.Errorf("Expected Kakao (first tick) not to be highlighted")
+	}
+}
+
+func TestFormatAlertEvent(t *testing.T) {
+	ev := models.AlertEvent{
+		Alert: models.Alert{Code: "005930", Name: "삼성전자", Condition: models.AlertAbove, Threshold: 80000},
+		Stock: models.Stock{Code: "005930", Price: 80100, ChangePercent: 1.2, IsRising: true},
+	}
+	expected := "삼성전자 (005930) 80,100 ▲1.20% [above 80,000]"
+	if got := FormatAlertEvent(ev); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+
+	ev.Alert.Condition = models.AlertChangePct
+	ev.Alert.Threshold = 5
+	if got := DescribeAlertCondition(ev.Alert); got != "change ±5%" {
+		t.Errorf("Expected change-percent description, got %q", got)
+	}
+}
@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/mattn/go-isatty"
+)
+
+// IsInteractive reports whether stdin is a terminal that can run pickers and prompts.
+func IsInteractive() bool {
+	return isatty.IsTerminal(os.Stdin.Fd()) || isatty.IsCygwinTerminal(os.Stdin.Fd())
+}
+
+// RunInput asks for a single line of text. Leading and trailing spaces are trimmed
+// and an empty answer is rejected.
+func RunInput(title, placeholder string) (string, error) {
+	if !IsInteractive() {
+		return "", fmt.Errorf("input requires an interactive terminal")
+	}
+
+	var value string
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title(title).
+				Placeholder(placeholder).
+				Validate(func(s string) error {
+					if strings.TrimSpace(s) == "" {
+						return fmt.Errorf("please enter a value")
+					}
+					return nil
+				}).
+				Value(&value),
+		),
+	)
+
+	theme := huh.ThemeCharm()
+	theme.Focused.Title = StyleNameActive
+	theme.Blurred.Title = StyleNameInactive
+	form.WithTheme(theme)
+
+	if err := form.Run(); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(value), nil
+}
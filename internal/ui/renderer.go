@@ -72,7 +72,11 @@ func RenderStockTable(stocks []StockViewModel) string {
 	for _, s := range stocks {
 		name := GetStyle(s.NameStyle).Copy().Width(maxNameWidth).Render(s.Name)
 
-		price := StylePrice.Copy().
+		priceStyle := StylePrice.Copy()
+		if s.Highlight {
+			priceStyle = GetStyle(s.TickStyle).Copy().Bold(true).Reverse(true)
+		}
+		price := priceStyle.
 			Width(maxPriceWidth).
 			Align(lipgloss.Right).
 			Render(s.Price)
@@ -115,3 +119,182 @@ func RenderListTable(items []ListItem) string {
 
 	return strings.Join(rows, "\n")
 }
+
+// RenderCandleTable renders a price history as a right-aligned table with a header row.
+func RenderCandleTable(candles []CandleViewModel) string {
+	if len(candles) == 0 {
+		return StyleNameInactive.Render("No price history found.")
+	}
+
+	headers := []string{"Date", "Open", "High", "Low", "Close", "Volume"}
+	widths := make([]int, len(headers))
+	for i, h := range headers {
+		widths[i] = lipgloss.Width(h)
+	}
+
+	cells := func(c CandleViewModel) []string {
+		return []string{c.Date, c.Open, c.High, c.Low, c.Close, c.Volume}
+	}
+	for _, c := range candles {
+		for i, v := range cells(c) {
+			if w := lipgloss.Width(v); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+
+	var headerCols []string
+	for i, h := range headers {
+		align := lipgloss.Right
+		if i == 0 {
+			align = lipgloss.Left
+		}
+		headerCols = append(headerCols, StyleNameInactive.Copy().Width(widths[i]).Align(align).Render(h))
+	}
+
+	rows := []string{strings.Join(headerCols, "  ")}
+	for _, c := range candles {
+		values := cells(c)
+		cols := []string{StyleNameActive.Copy().Width(widths[0]).Render(values[0])}
+		for i := 1; i < len(values); i++ {
+			style := StyleNameInactive
+			if i == 4 {
+				style = StylePrice
+			}
+			cols = append(cols, style.Copy().Width(widths[i]).Align(lipgloss.Right).Render(values[i]))
+		}
+		cols = append(cols, GetStyle(c.ChangeStyle).Render(c.ChangeInfo))
+		rows = append(rows, strings.Join(cols, "  "))
+	}
+
+	return strings.Join(rows, "\n")
+}
+
+// RenderPositionTable renders portfolio positions with market value and unrealized P&L,
+// followed by a totals footer.
+func RenderPositionTable(positions []PositionViewModel, totals PositionTotalsViewModel) string {
+	if len(positions) == 0 {
+		return ""
+	}
+
+	headers := []string{"Name", "Price", "Change", "Qty", "Avg", "Value", "P&L", "P&L%"}
+	cells := func(p PositionViewModel) []string {
+		return []string{p.Name, p.Price, p.ChangeInfo, p.Quantity, p.AvgPrice, p.MarketValue, p.PnL, p.PnLPercent}
+	}
+	footer := []string{"Total", "", "", "", "", totals.MarketValue, totals.PnL, totals.PnLPercent}
+
+	widths := make([]int, len(headers))
+	for i, h := range headers {
+		widths[i] = lipgloss.Width(h)
+	}
+	rowsToMeasure := [][]string{footer}
+	for _, p := range positions {
+		rowsToMeasure = append(rowsToMeasure, cells(p))
+	}
+	for _, row := range rowsToMeasure {
+		for i, v := range row {
+			if w := lipgloss.Width(v); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+
+	render := func(values []string, styles []lipgloss.Style) string {
+		cols := make([]string, len(values))
+		for i, v := range values {
+			align := lipgloss.Right
+			if i == 0 || i == 2 {
+				align = lipgloss.Left
+			}
+			cols[i] = styles[i].Copy().Width(widths[i]).Align(align).Render(v)
+		}
+		return strings.TrimRight(strings.Join(cols, "  "), " ")
+	}
+
+	headerStyles := make([]lipgloss.Style, len(headers))
+	for i := range headerStyles {
+		headerStyles[i] = StyleNameInactive
+	}
+
+	rows := []string{render(headers, headerStyles)}
+	for _, p := range positions {
+		pnl := GetStyle(p.PnLStyle)
+		rows = append(rows, render(cells(p), []lipgloss.Style{
+			GetStyle(p.NameStyle),
+			StylePrice,
+			GetStyle(p.ChangeStyle),
+			StyleNameInactive,
+			StyleNameInactive,
+			StylePrice,
+			pnl,
+			pnl,
+		}))
+	}
+
+	totalPnL := GetStyle(totals.PnLStyle)
+	rows = append(rows, render(footer, []lipgloss.Style{
+		StyleNameActive,
+		StyleNameInactive,
+		StyleNameInactive,
+		StyleNameInactive,
+		StyleNameInactive,
+		StylePrice,
+		totalPnL,
+		totalPnL,
+	}))
+
+	return strings.Join(rows, "\n")
+}
+
+// RenderExplanations renders resolution chains. Portfolio inputs get a header line
+// with their items indented below it.
+func RenderExplanations(explanations []ExplanationViewModel) string {
+	inputWidth, sourceWidth := 0, 0
+	for _, e := range explanations {
+		for _, s := range e.Steps {
+			inputWidth = max(inputWidth, lipgloss.Width(s.Input))
+			sourceWidth = max(sourceWidth, lipgloss.Width(s.Source))
+		}
+	}
+
+	var blocks []string
+	for _, e := range explanations {
+		var lines []string
+		indent := ""
+		if e.Summary != "" {
+			lines = append(lines, StyleNameActive.Render(e.Summary))
+			for _, n := range e.Notes {
+				lines = append(lines, "  "+StyleHelpWarning.Render("! "+n))
+			}
+			indent = "  "
+		}
+
+		for _, s := range e.Steps {
+			inputStyle, resultStyle := StyleNameActive, StylePrice
+			if s.Dropped {
+				inputStyle, resultStyle = StyleNameInactive, StyleNameInactive
+			}
+
+			lines = append(lines, fmt.Sprintf("%s%s  %s  %s",
+				indent,
+				inputStyle.Copy().Width(inputWidth).Render(s.Input),
+				StyleNameInactive.Copy().Width(sourceWidth).Render(s.Source),
+				resultStyle.Render(s.Result),
+			))
+
+			detail := indent + strings.Repeat(" ", inputWidth+2)
+			for _, n := range s.Notes {
+				lines = append(lines, detail+StyleHelpWarning.Render("! "+n))
+			}
+			if len(s.Candidates) > 0 {
+				for _, row := range strings.Split(RenderListTable(s.Candidates), "\n") {
+					lines = append(lines, detail+row)
+				}
+			}
+		}
+
+		blocks = append(blocks, strings.Join(lines, "\n"))
+	}
+
+	return strings.Join(blocks, "\n")
+}
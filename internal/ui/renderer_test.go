@@ -3,6 +3,7 @@ package ui
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ericyhkim/juga/pkg/models"
 )
@@ -115,4 +116,55 @@ func TestRenderIndices(t *testing.T) {
 	if !strings.Contains(result, " | ") {
 		t.Errorf("RenderIndices() missing separator")
 	}
-}
\ No newline at end of file
+}
+
+func TestRenderCandleTable(t *testing.T) {
+	candles := []models.Candle{
+		{Date: time.Date(2024, 1, 2, 0, 0, 0, 0, time.Local), Open: 78200, High: 79800, Low: 78200, Close: 79600, Volume: 17142847},
+		{Date: time.Date(2024, 1, 3, 0, 0, 0, 0, time.Local), Open: 78500, High: 78800, Low: 77000, Close: 77000, Volume: 21753644},
+	}
+
+	p := NewPresenter()
+	vms := p.PrepareCandles(candles)
+
+	if vms[1].ChangeStyle != StyleFall {
+		t.Errorf("Expected second candle to be falling, got %v", vms[1].ChangeStyle)
+	}
+
+	result := RenderCandleTable(vms)
+	lines := strings.Split(result, "\n")
+
+	if len(lines) != 3 {
+		t.Fatalf("Expected header + 2 lines, got %d", len(lines))
+	}
+	if !strings.Contains(result, "2024-01-02") || !strings.Contains(result, "79,600") {
+		t.Errorf("RenderCandleTable() missing first candle: %s", result)
+	}
+	if !strings.Contains(result, "21,753,644") {
+		t.Errorf("RenderCandleTable() missing formatted volume")
+	}
+}
+
+func TestRenderPositionTable(t *testing.T) {
+	positions := []models.Position{
+		{Stock: models.Stock{Name: "Samsung", Price: 80000, Change: 1000, ChangePercent: 1.27, IsRising: true}, Quantity: 10, AvgPrice: 70000},
+		{Stock: models.Stock{Name: "Naver", Price: 200000}},
+	}
+
+	p := NewPresenter()
+	result := RenderPositionTable(p.PreparePositions(positions))
+	lines := strings.Split(result, "\n")
+
+	if len(lines) != 4 {
+		t.Fatalf("Expected header + 2 rows + footer, got %d lines:\n%s", len(lines), result)
+	}
+	if !strings.Contains(lines[0], "P&L%") {
+		t.Errorf("Missing header: %s", lines[0])
+	}
+	if !strings.Contains(lines[1], "800,000") || !strings.Contains(lines[1], "14.29%") {
+		t.Errorf("Missing position values: %s", lines[1])
+	}
+	if !strings.Contains(lines[3], "Total") || !strings.Contains(lines[3], "100,000") {
+		t.Errorf("Missing totals footer: %s", lines[3])
+	}
+}
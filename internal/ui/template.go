@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/ericyhkim/juga/pkg/models"
+)
+
+// TemplateFuncs exposes the presenter's formatting helpers to user-defined
+// --format templates so custom layouts render numbers the same way as the table.
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"formatNumber":     formatNumber,
+		"formatLargeValue": formatLargeValue,
+		"direction":        getDirectionSymbol,
+		"percent": func(v float64) string {
+			return fmt.Sprintf("%.2f%%", v)
+		},
+		"colorize": func(s models.Stock, text string) string {
+			return GetStyle(directionStyle(s)).Render(text)
+		},
+		"rise": func(text string) string {
+			return GetStyle(StyleRise).Render(text)
+		},
+		"fall": func(text string) string {
+			return GetStyle(StyleFall).Render(text)
+		},
+		"dim": func(text string) string {
+			return GetStyle(StyleNeutral).Render(text)
+		},
+		"bold": func(text string) string {
+			return StylePrice.Render(text)
+		},
+	}
+}
+
+// ParseStockTemplate compiles a --format template evaluated against models.Stock.
+func ParseStockTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("format").Funcs(TemplateFuncs()).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid format template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// RenderStockTemplate executes tmpl once per stock, writing each result on its own line.
+func RenderStockTemplate(w io.Writer, tmpl *template.Template, stocks []models.Stock) error {
+	for _, s := range stocks {
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, s); err != nil {
+			return fmt.Errorf("failed to render %s: %w", s.Name, err)
+		}
+		line := sb.String()
+		if !strings.HasSuffix(line, "\n") {
+			line += "\n"
+		}
+		if _, err := io.WriteString(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func directionStyle(s models.Stock) StyleType {
+	if s.IsRising {
+		return StyleRise
+	}
+	if s.IsFalling {
+		return StyleFall
+	}
+	return StyleNeutral
+}
@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ericyhkim/juga/pkg/models"
+)
+
+func TestRenderStockTemplate(t *testing.T) {
+	stocks := []models.Stock{
+		{Name: "삼성전자", Price: 75000, Change: 1000, ChangePercent: 1.35, TradingValue: 1234567, IsRising: true},
+		{Name: "SK하이닉스", Price: 130000, Change: -2500, ChangePercent: -1.89, IsFalling: true},
+	}
+
+	tmpl, err := ParseStockTemplate("{{.Name}} {{formatNumber .Price}} {{direction .}}{{percent .ChangePercent}} {{formatLargeValue .TradingValue}}")
+	if err != nil {
+		t.Fatalf("ParseStockTemplate failed: %v", err)
+	}
+
+	var sb strings.Builder
+	if err := RenderStockTemplate(&sb, tmpl, stocks); err != nil {
+		t.Fatalf("RenderStockTemplate failed: %v", err)
+	}
+
+	expected := "삼성전자 75,000 ▲1.35% 1.2T\nSK하이닉스 130,000 ▼-1.89% 0.0M\n"
+	if sb.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, sb.String())
+	}
+}
+
+func TestParseStockTemplate_Invalid(t *testing.T) {
+	if _, err := ParseStockTemplate("{{.Name"); err == nil {
+		t.Error("Expected error for unterminated action")
+	}
+	if _, err := ParseStockTemplate("{{unknownFunc .Name}}"); err == nil {
+		t.Error("Expected error for unknown function")
+	}
+}
+
+func TestRenderStockTemplate_UnknownField(t *testing.T) {
+	tmpl, err := ParseStockTemplate("{{.Nope}}")
+	if err != nil {
+		t.Fatalf("ParseStockTemplate failed: %v", err)
+	}
+	var sb strings.Builder
+	if err := RenderStockTemplate(&sb, tmpl, []models.Stock{{Name: "A"}}); err == nil {
+		t.Error("Expected error for unknown field")
+	}
+}
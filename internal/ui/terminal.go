@@ -0,0 +1,22 @@
+package ui
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/charmbracelet/x/term"
+)
+
+const defaultTerminalWidth = 80
+
+// TerminalWidth returns the width of the attached terminal.
+// It falls back to $COLUMNS and then to 80 columns when stdout is not a terminal.
+func TerminalWidth() int {
+	if w, _, err := term.GetSize(os.Stdout.Fd()); err == nil && w > 0 {
+		return w
+	}
+	if cols, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && cols > 0 {
+		return cols
+	}
+	return defaultTerminalWidth
+}
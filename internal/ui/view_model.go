@@ -22,4 +22,62 @@ type StockViewModel struct {
 	High         string
 	Low          string
 	TradingValue string
-}
\ No newline at end of file
+
+	// Highlight marks a price that changed since the previous refresh (watch mode).
+	// TickStyle tells whether it moved up or down.
+	Highlight bool
+	TickStyle StyleType
+}
+
+// CandleViewModel represents a single formatted row of price history.
+type CandleViewModel struct {
+	Date        string
+	Open        string
+	High        string
+	Low         string
+	Close       string
+	Volume      string
+	ChangeInfo  string
+	ChangeStyle StyleType
+}
+
+// PositionViewModel represents a portfolio row with its position details.
+// Position fields are empty for watch-only entries.
+type PositionViewModel struct {
+	StockViewModel
+
+	Quantity    string
+	AvgPrice    string
+	MarketValue string
+	PnL         string
+	PnLPercent  string
+	PnLStyle    StyleType
+}
+
+// PositionTotalsViewModel represents the totals footer of a portfolio.
+type PositionTotalsViewModel struct {
+	Cost        string
+	MarketValue string
+	PnL         string
+	PnLPercent  string
+	PnLStyle    StyleType
+}
+
+// ExplanationViewModel describes how one command-line input was resolved.
+// Summary is set when the input expanded to a portfolio.
+type ExplanationViewModel struct {
+	Summary string
+	Notes   []string
+	Steps   []ExplainStepViewModel
+}
+
+// ExplainStepViewModel is a single resolved item. Dropped items are duplicates that
+// are not fetched.
+type ExplainStepViewModel struct {
+	Input      string
+	Source     string
+	Result     string
+	Notes      []string
+	Candidates []ListItem
+	Dropped    bool
+}
@@ -3,8 +3,42 @@ package config
 import "time"
 
 const (
-	DefaultMaxStocks      = 20
+	// DefaultQuoteBatchSize is how many codes go into one quote request; larger lists
+	// are split and fetched by up to DefaultQuoteWorkers requests at a time.
+	DefaultQuoteBatchSize = 20
+	DefaultQuoteWorkers   = 4
 	DefaultCacheSize      = 100
 	DefaultClientTimeout  = 2 * time.Second
 	DefaultScraperTimeout = 10 * time.Second
+	// DefaultClientRetries is how often a quote request that timed out or got a 5xx
+	// response is retried, waiting DefaultClientBackoff (doubling each time) in between.
+	DefaultClientRetries = 2
+	DefaultClientBackoff = 200 * time.Millisecond
+	// DefaultFindLimit is how many matches `juga find` lists unless --limit is given.
+	DefaultFindLimit = 10
+
+	DefaultWatchInterval = 5 * time.Second
+	// MaxWatchInterval caps the refresh back-off while the market is closed.
+	MaxWatchInterval = 5 * time.Minute
+
+	DefaultAlertInterval = 30 * time.Second
+	// DefaultAlertPriceHysteresis is how far (as a fraction of the threshold) the price
+	// must retreat before a fired above/below alert can fire again.
+	DefaultAlertPriceHysteresis = 0.005
+	// DefaultAlertPercentHysteresis is the same band for change-percent alerts, in
+	// percentage points.
+	DefaultAlertPercentHysteresis = 0.5
+
+	DefaultServeAddr = "127.0.0.1:8080"
+	// DefaultServeCacheTTL is how long the API server reuses quotes fetched from Naver.
+	DefaultServeCacheTTL = 3 * time.Second
+
+	DefaultExporterAddr     = ":9109"
+	DefaultExporterInterval = 15 * time.Second
+
+	// DefaultBrokerFeeRate is a typical online brokerage commission (0.015%).
+	DefaultBrokerFeeRate = 0.00015
+	// DefaultTransactionTaxRate is the securities transaction tax charged on sells,
+	// including the rural development special tax (0.20% as of 2026).
+	DefaultTransactionTaxRate = 0.002
 )
@@ -8,8 +8,10 @@ import (
 )
 
 const (
+	AlertsFileName        = "alerts.json"
 	AliasesFileName       = "aliases.json"
 	CacheFileName         = "cache.json"
+	LedgerFileName        = "ledger.json"
 	MasterTickersFileName = "master_tickers.csv"
 	PortfoliosFileName    = "portfolios.json"
 
@@ -111,6 +113,14 @@ func GetAliasesPath() (string, error) {
 	return filepath.Join(dir, AliasesFileName), nil
 }
 
+func GetAlertsPath() (string, error) {
+	dir, err := getConfigHome()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, AlertsFileName), nil
+}
+
 func GetCachePath() (string, error) {
 	dir, err := getCacheHome()
 	if err != nil {
@@ -127,6 +137,14 @@ func GetPortfoliosPath() (string, error) {
 	return filepath.Join(dir, PortfoliosFileName), nil
 }
 
+func GetLedgerPath() (string, error) {
+	dir, err := getConfigHome()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, LedgerFileName), nil
+}
+
 func GetMasterTickersPath() (string, error) {
 	dir, err := getDataHome()
 	if err != nil {
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// EnvQuoteProvider selects the primary quote provider ("naver" or "daum"). The
+// other providers are only used when the primary fails.
+const EnvQuoteProvider = "JUGA_QUOTE_PROVIDER"
+
+// EnvNaverBaseURL sends all Naver requests to another server, such as a naverfake
+// server for offline demos and integration tests. Daum is not used as a fallback
+// while it is set.
+const EnvNaverBaseURL = "JUGA_NAVER_BASE_URL"
+
+// DefaultQuoteProvider is the primary quote provider unless JUGA_QUOTE_PROVIDER is set.
+const DefaultQuoteProvider = "naver"
+
+// GetQuoteProvider returns the name of the primary quote provider.
+func GetQuoteProvider() string {
+	if name := strings.TrimSpace(os.Getenv(EnvQuoteProvider)); name != "" {
+		return strings.ToLower(name)
+	}
+	return DefaultQuoteProvider
+}
+
+// GetNaverBaseURL returns the server replacing the Naver hosts, or "" to use Naver.
+func GetNaverBaseURL() string {
+	return strings.TrimSpace(os.Getenv(EnvNaverBaseURL))
+}
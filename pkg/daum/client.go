@@ -0,0 +1,183 @@
+// Package daum fetches quotes from Daum Finance. It serves as a fallback for the
+// Naver polling API and only supports quotes.
+package daum
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ericyhkim/juga/pkg/diag"
+	"github.com/ericyhkim/juga/pkg/models"
+	"github.com/ericyhkim/juga/pkg/quote"
+)
+
+// ProviderName identifies Daum Finance as a quote provider.
+const ProviderName = "daum"
+
+const (
+	daumQuoteURL = "https://finance.daum.net/api/quotes/"
+	// The API rejects requests without a Referer from its own site.
+	daumReferer   = "https://finance.daum.net/"
+	daumUserAgent = "Mozilla/5.0 (compatible; juga)"
+
+	defaultTimeout = 2 * time.Second
+	defaultWorkers = 4
+)
+
+type Client struct {
+	httpClient *http.Client
+	logger     diag.Logger
+	baseURL    string
+	workers    int
+}
+
+type ClientOption func(*Client)
+
+// WithTimeout limits how long a single request may take.
+func WithTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.httpClient.Timeout = d
+	}
+}
+
+// WithTransport sends requests through rt, e.g. to record or replay them.
+func WithTransport(rt http.RoundTripper) ClientOption {
+	return func(c *Client) {
+		c.httpClient.Transport = rt
+	}
+}
+
+// WithWorkers sets how many quotes are requested concurrently. Daum serves one
+// stock per request.
+func WithWorkers(workers int) ClientOption {
+	return func(c *Client) {
+		c.workers = max(workers, 1)
+	}
+}
+
+func NewClient(logger diag.Logger, opts ...ClientOption) *Client {
+	c := &Client{
+		httpClient: &http.Client{
+			Timeout: defaultTimeout,
+		},
+		logger:  logger,
+		baseURL: daumQuoteURL,
+		workers: defaultWorkers,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+func (c *Client) Name() string {
+	return ProviderName
+}
+
+// Capabilities reports that Daum only serves quotes.
+func (c *Client) Capabilities() quote.Capability {
+	return quote.CapQuotes
+}
+
+// FetchStocks fetches quotes for codes, in the order of codes. Codes that fail are
+// left out and reported as a warning. The call only fails when every code does, so
+// that a fallback provider can take over.
+func (c *Client) FetchStocks(ctx context.Context, codes []string) ([]models.Stock, error) {
+	if len(codes) == 0 {
+		return []models.Stock{}, nil
+	}
+
+	stocks := make([]models.Stock, len(codes))
+	errs := make([]error, len(codes))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for range min(c.workers, len(codes)) {
+		wg.Go(func() {
+			for i := range jobs {
+				stocks[i], errs[i] = c.fetchQuote(ctx, codes[i])
+			}
+		})
+	}
+	for i := range codes {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	fetched := make([]models.Stock, 0, len(codes))
+	var failed []string
+	for i, code := range codes {
+		if errs[i] != nil {
+			failed = append(failed, code)
+			continue
+		}
+		fetched = append(fetched, stocks[i])
+	}
+
+	if len(fetched) == 0 {
+		return nil, quote.FirstError(errs)
+	}
+	if len(failed) > 0 {
+		c.logger.Warn("Failed to fetch %s from Daum: %v", strings.Join(failed, ", "), quote.FirstError(errs))
+	}
+	return fetched, nil
+}
+
+// FetchIndices is not supported by Daum.
+func (c *Client) FetchIndices(ctx context.Context) ([]models.Stock, error) {
+	return nil, quote.ErrUnsupported
+}
+
+// FetchCandles is not supported by Daum.
+func (c *Client) FetchCandles(ctx context.Context, code string, interval models.CandleInterval, from, to time.Time) ([]models.Candle, error) {
+	return nil, quote.ErrUnsupported
+}
+
+func (c *Client) fetchQuote(ctx context.Context, code string) (models.Stock, error) {
+	url := c.baseURL + symbolCode(code)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return models.Stock{}, err
+	}
+	req.Header.Set("Referer", daumReferer)
+	req.Header.Set("User-Agent", daumUserAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return models.Stock{}, ctxErr
+		}
+		return models.Stock{}, &quote.NetworkError{Provider: ProviderName, URL: url, Err: err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return models.Stock{}, &quote.StatusError{Provider: ProviderName, URL: url, StatusCode: resp.StatusCode}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return models.Stock{}, ctxErr
+		}
+		return models.Stock{}, &quote.NetworkError{Provider: ProviderName, URL: url, Err: err}
+	}
+
+	var q DaumQuote
+	if err := json.Unmarshal(body, &q); err != nil {
+		return models.Stock{}, &quote.DecodeError{Provider: ProviderName, URL: url, Err: err}
+	}
+	return MapToStock(q), nil
+}
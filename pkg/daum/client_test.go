@@ -0,0 +1,112 @@
+package daum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ericyhkim/juga/pkg/diag"
+	"github.com/ericyhkim/juga/pkg/quote"
+)
+
+func TestMapToStock(t *testing.T) {
+	q := DaumQuote{
+		SymbolCode:    "A005930",
+		Name:          "삼성전자",
+		TradePrice:    75200,
+		Change:        "FALL",
+		ChangePrice:   200,
+		ChangeRate:    0.0026525199,
+		HighPrice:     76000,
+		LowPrice:      75000,
+		AccTradePrice: 1_234_567_890_123,
+	}
+
+	s := MapToStock(q)
+
+	if s.Code != "005930" || s.Name != "삼성전자" {
+		t.Errorf("Unexpected identity: %s %s", s.Code, s.Name)
+	}
+	if s.Price != 75200 || s.High != 76000 || s.Low != 75000 {
+		t.Errorf("Unexpected prices: %+v", s)
+	}
+	if s.Change != -200 || s.ChangePercent != -0.27 {
+		t.Errorf("Expected a signed change of -200 (-0.27%%), got %f (%f%%)", s.Change, s.ChangePercent)
+	}
+	if s.TradingValue != 1234568 {
+		t.Errorf("Expected TradingValue in millions (1234568), got %f", s.TradingValue)
+	}
+	if !s.IsFalling || s.IsRising {
+		t.Error("Expected IsFalling only")
+	}
+
+	if up := MapToStock(DaumQuote{Change: "UPPER_LIMIT", ChangePrice: 100}); !up.IsRising || up.Change != 100 {
+		t.Errorf("Expected an upper limit to be rising, got %+v", up)
+	}
+}
+
+type warnLogger struct {
+	diag.NopLogger
+	warnings []string
+}
+
+func (l *warnLogger) Warn(format string, v ...interface{}) {
+	l.warnings = append(l.warnings, fmt.Sprintf(format, v...))
+}
+
+func TestClientFetchStocks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Referer") == "" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		symbol := strings.TrimPrefix(r.URL.Path, "/")
+		if symbol == "A999999" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(w, `{"symbolCode":%q,"name":"stock","tradePrice":1000,"change":"RISE","changePrice":10}`, symbol)
+	}))
+	defer srv.Close()
+
+	logger := &warnLogger{}
+	c := NewClient(logger, WithWorkers(2))
+	c.baseURL = srv.URL + "/"
+
+	codes := []string{"005930", "000660", "035720"}
+	stocks, err := c.FetchStocks(context.Background(), codes)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for i, s := range stocks {
+		if s.Code != codes[i] || s.Price != 1000 || !s.IsRising {
+			t.Errorf("Unexpected stock %d: %+v", i, s)
+		}
+	}
+
+	// A failed code is left out and reported, the others are still returned.
+	stocks, err = c.FetchStocks(context.Background(), []string{"005930", "999999", "000660"})
+	if err != nil {
+		t.Fatalf("Unexpected error for a partly failed batch: %v", err)
+	}
+	if len(stocks) != 2 || stocks[0].Code != "005930" || stocks[1].Code != "000660" {
+		t.Errorf("Expected the quotes that succeeded, got %+v", stocks)
+	}
+	if len(logger.warnings) != 1 || !strings.Contains(logger.warnings[0], "999999") || strings.Contains(logger.warnings[0], "005930") {
+		t.Errorf("Expected a warning naming only the failed code, got %q", logger.warnings)
+	}
+
+	_, err = c.FetchStocks(context.Background(), []string{"999999"})
+	var statusErr *quote.StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound || statusErr.Provider != ProviderName {
+		t.Errorf("Expected a 404 StatusError from daum, got %v", err)
+	}
+
+	if _, err := c.FetchIndices(context.Background()); !errors.Is(err, quote.ErrUnsupported) {
+		t.Errorf("Expected indices to be unsupported, got %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+package daum
+
+import (
+	"math"
+
+	"github.com/ericyhkim/juga/pkg/models"
+)
+
+// DaumQuote represents a quote returned by the Daum Finance quotes API. Unlike
+// Naver, Daum returns plain numbers, with the change as an absolute value whose
+// direction is given by Change.
+type DaumQuote struct {
+	SymbolCode    string  `json:"symbolCode"`
+	Name          string  `json:"name"`
+	TradePrice    float64 `json:"tradePrice"`
+	Change        string  `json:"change"`
+	ChangePrice   float64 `json:"changePrice"`
+	ChangeRate    float64 `json:"changeRate"`
+	HighPrice     float64 `json:"highPrice"`
+	LowPrice      float64 `json:"lowPrice"`
+	AccTradePrice float64 `json:"accTradePrice"`
+	MarketStatus  string  `json:"marketStatus"`
+}
+
+// Directions of Change.
+const (
+	changeRise       = "RISE"
+	changeUpperLimit = "UPPER_LIMIT"
+	changeFall       = "FALL"
+	changeLowerLimit = "LOWER_LIMIT"
+)
+
+// MapToStock converts a Daum quote to the shape Naver quotes are mapped to: the
+// change is signed, the change rate is a percentage and the trading value is in
+// millions of KRW.
+func MapToStock(q DaumQuote) models.Stock {
+	rising := q.Change == changeRise || q.Change == changeUpperLimit
+	falling := q.Change == changeFall || q.Change == changeLowerLimit
+
+	sign := 1.0
+	if falling {
+		sign = -1.0
+	}
+
+	return models.Stock{
+		Code:          stockCode(q.SymbolCode),
+		Name:          q.Name,
+		Price:         q.TradePrice,
+		Change:        sign * math.Abs(q.ChangePrice),
+		ChangePercent: sign * math.Round(math.Abs(q.ChangeRate)*10000) / 100,
+		High:          q.HighPrice,
+		Low:           q.LowPrice,
+		TradingValue:  math.Round(q.AccTradePrice / 1_000_000),
+		IsRising:      rising,
+		IsFalling:     falling,
+		MarketStatus:  q.MarketStatus,
+	}
+}
+
+// symbolCode turns a stock code into a Daum symbol ("005930" → "A005930").
+func symbolCode(code string) string {
+	return "A" + code
+}
+
+func stockCode(symbol string) string {
+	if len(symbol) == models.StockCodeLength+1 && symbol[0] == 'A' {
+		return symbol[1:]
+	}
+	return symbol
+}
@@ -19,10 +19,13 @@ var (
 	styleDebug = lipgloss.NewStyle().Foreground(lipgloss.Color("#626262"))
 )
 
-type StdLogger struct{}
+// StdLogger writes to stderr. Debug messages are only written when verbose is set.
+type StdLogger struct {
+	verbose bool
+}
 
-func NewStdLogger() *StdLogger {
-	return &StdLogger{}
+func NewStdLogger(verbose bool) *StdLogger {
+	return &StdLogger{verbose: verbose}
 }
 
 func (l *StdLogger) Error(format string, v ...interface{}) {
@@ -36,6 +39,9 @@ func (l *StdLogger) Warn(format string, v ...interface{}) {
 }
 
 func (l *StdLogger) Debug(format string, v ...interface{}) {
+	if !l.verbose {
+		return
+	}
 	msg := fmt.Sprintf(format, v...)
 	fmt.Fprintln(os.Stderr, styleDebug.Render(msg))
 }
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+type AlertCondition string
+
+const (
+	AlertAbove AlertCondition = "above"
+	AlertBelow AlertCondition = "below"
+	// AlertChangePct fires when the day's change moves at least Threshold percent
+	// in either direction.
+	AlertChangePct AlertCondition = "change_pct"
+)
+
+// Alert watches a single stock for a price condition. Triggered records that the
+// condition has fired and stays set until the price moves back past the hysteresis
+// band, so every crossing fires exactly once.
+type Alert struct {
+	ID        int            `json:"id"`
+	Item      string         `json:"item"`
+	Code      string         `json:"code"`
+	Name      string         `json:"name,omitempty"`
+	Condition AlertCondition `json:"condition"`
+	Threshold float64        `json:"threshold"`
+	Command   string         `json:"command,omitempty"`
+	Triggered bool           `json:"triggered"`
+	LastFired time.Time      `json:"last_fired"`
+}
+
+// AlertEvent is produced when an alert's condition is crossed.
+type AlertEvent struct {
+	Alert Alert
+	Stock Stock
+}
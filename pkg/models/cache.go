@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// CacheEntry is a search term remembered with the code it resolved to.
+type CacheEntry struct {
+	Term      string    `json:"term"`
+	Code      string    `json:"code"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
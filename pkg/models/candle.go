@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// CandleInterval is the time frame a single candle covers.
+type CandleInterval string
+
+const (
+	IntervalDay   CandleInterval = "day"
+	IntervalWeek  CandleInterval = "week"
+	IntervalMonth CandleInterval = "month"
+)
+
+// Candle is a single OHLCV bar of a stock's price history.
+type Candle struct {
+	Date   time.Time
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	Volume float64
+}
+
+// ParseCandleInterval maps user input (e.g. "d", "week") to a CandleInterval.
+func ParseCandleInterval(s string) (CandleInterval, bool) {
+	switch s {
+	case "d", "day", "daily":
+		return IntervalDay, true
+	case "w", "week", "weekly":
+		return IntervalWeek, true
+	case "m", "month", "monthly":
+		return IntervalMonth, true
+	}
+	return "", false
+}
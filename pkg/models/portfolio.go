@@ -0,0 +1,45 @@
+package models
+
+// Holding is a single entry of a portfolio. Item is the raw input (name, code or alias)
+// resolved at query time. Quantity and AvgPrice are zero for watch-only entries.
+type Holding struct {
+	Item     string  `json:"item"`
+	Quantity float64 `json:"quantity,omitempty"`
+	AvgPrice float64 `json:"avg_price,omitempty"`
+}
+
+// HasPosition reports whether the holding carries an actual position.
+func (h Holding) HasPosition() bool {
+	return h.Quantity != 0
+}
+
+// Position combines a holding with its current quote.
+type Position struct {
+	Stock    Stock
+	Quantity float64
+	AvgPrice float64
+}
+
+func (p Position) HasPosition() bool {
+	return p.Quantity != 0
+}
+
+func (p Position) Cost() float64 {
+	return p.Quantity * p.AvgPrice
+}
+
+func (p Position) MarketValue() float64 {
+	return p.Quantity * p.Stock.Price
+}
+
+func (p Position) PnL() float64 {
+	return p.MarketValue() - p.Cost()
+}
+
+func (p Position) PnLPercent() float64 {
+	cost := p.Cost()
+	if cost == 0 {
+		return 0
+	}
+	return p.PnL() / cost * 100
+}
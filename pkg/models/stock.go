@@ -1,5 +1,7 @@
 package models
 
+import "strings"
+
 const StockCodeLength = 6
 
 const (
@@ -10,17 +12,24 @@ const (
 )
 
 type Stock struct {
-	Code          string
-	Name          string
-	Price         float64
-	Change        float64
-	ChangePercent float64
-	High          float64
-	Low           float64
-	TradingValue  float64
-	IsRising      bool
-	IsFalling     bool
-	MarketStatus  string
+	Code          string  `json:"code"`
+	Name          string  `json:"name"`
+	Price         float64 `json:"price"`
+	Change        float64 `json:"change"`
+	ChangePercent float64 `json:"change_percent"`
+	High          float64 `json:"high"`
+	Low           float64 `json:"low"`
+	TradingValue  float64 `json:"trading_value"`
+	IsRising      bool    `json:"is_rising"`
+	IsFalling     bool    `json:"is_falling"`
+	MarketStatus  string  `json:"market_status"`
+}
+
+// IsMarketOpen checks if the market status indicates active trading.
+// Naver returns various strings like "OPEN", "CLOSE", "DELAY".
+func (s Stock) IsMarketOpen() bool {
+	status := strings.ToUpper(s.MarketStatus)
+	return status == "OPEN" || status == "장중" // "장중" is Korean for "During Market"
 }
 
 func IsValidCode(s string) bool {
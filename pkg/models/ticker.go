@@ -1,7 +1,14 @@
 package models
 
 type Ticker struct {
-	Code   string
-	Name   string
-	Market string
+	Code   string `json:"code"`
+	Name   string `json:"name"`
+	Market string `json:"market"`
+	// EnglishName is Naver's English listing name (e.g. "SK hynix"). It is only
+	// used for searching; the Korean Name is always what gets displayed.
+	EnglishName string `json:"english_name,omitempty"`
+	// Rank is the market-cap rank across all markets (1 = largest), or 0 if unknown.
+	Rank int `json:"rank,omitempty"`
+	// ETF marks exchange-traded funds, which are listed on KOSPI alongside stocks.
+	ETF bool `json:"etf,omitempty"`
 }
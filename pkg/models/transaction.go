@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+type TransactionType string
+
+const (
+	TxBuy      TransactionType = "buy"
+	TxSell     TransactionType = "sell"
+	TxDividend TransactionType = "dividend"
+)
+
+// Transaction is a single entry of a portfolio's append-only ledger.
+// For dividends, Quantity is the number of shares and Price the amount paid per share.
+// Fee and Tax are computed when the transaction is recorded, so later changes to
+// LedgerSettings do not rewrite history.
+type Transaction struct {
+	Type     TransactionType `json:"type"`
+	Date     time.Time       `json:"date"`
+	Item     string          `json:"item"`
+	Code     string          `json:"code"`
+	Name     string          `json:"name,omitempty"`
+	Quantity float64         `json:"quantity"`
+	Price    float64         `json:"price"`
+	Fee      float64         `json:"fee,omitempty"`
+	Tax      float64         `json:"tax,omitempty"`
+}
+
+func (t Transaction) Amount() float64 {
+	return t.Quantity * t.Price
+}
+
+// LedgerSettings holds per-portfolio trading costs as fractions (0.00015 = 0.015%).
+// FeeRate applies to buys and sells, TaxRate (securities transaction tax) to sells only.
+type LedgerSettings struct {
+	FeeRate float64 `json:"fee_rate"`
+	TaxRate float64 `json:"tax_rate"`
+}
+
+// LedgerPosition is the state of one stock derived from a ledger using average-cost accounting.
+// AvgPrice includes buy fees.
+type LedgerPosition struct {
+	Code           string
+	Name           string
+	Item           string
+	Quantity       float64
+	AvgPrice       float64
+	RealizedGain   float64
+	DividendIncome float64
+	Fees           float64
+	Taxes          float64
+}
+
+// LedgerReport summarizes a ledger per stock and in total.
+type LedgerReport struct {
+	Positions      []LedgerPosition
+	RealizedGain   float64
+	DividendIncome float64
+	Fees           float64
+	Taxes          float64
+}
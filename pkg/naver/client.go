@@ -1,41 +1,103 @@
 package naver
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
+	"errors"
+	"io"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ericyhkim/juga/pkg/diag"
 	"github.com/ericyhkim/juga/pkg/models"
+	"github.com/ericyhkim/juga/pkg/quote"
 )
 
+// ProviderName identifies Naver Finance as a quote provider.
+const ProviderName = "naver"
+
 const (
-	naverPollingURL = "https://polling.finance.naver.com/api/realtime/domestic/stock/"
-	naverIndexURL   = "https://polling.finance.naver.com/api/realtime/domestic/index/KOSPI,KOSDAQ"
+	naverPollingHost = "https://polling.finance.naver.com"
+	naverAPIHost     = "https://api.finance.naver.com"
+	naverFinanceHost = "https://finance.naver.com"
+
+	naverPollingPath = "/api/realtime/domestic/stock/"
+	naverIndexPath   = "/api/realtime/domestic/index/KOSPI,KOSDAQ"
+
+	defaultTimeout = 2 * time.Second
+	defaultRetries = 2
+	defaultBackoff = 200 * time.Millisecond
+
+	defaultBatchSize = 20
+	defaultWorkers   = 4
 )
 
 type Client struct {
 	httpClient *http.Client
 	logger     diag.Logger
+	retries    int
+	backoff    time.Duration
+	batchSize  int
+	workers    int
+	baseURL    string
 }
 
 type ClientOption func(*Client)
 
+// WithTimeout limits how long a single attempt of a request may take.
 func WithTimeout(d time.Duration) ClientOption {
 	return func(c *Client) {
 		c.httpClient.Timeout = d
 	}
 }
 
+// WithRetries sets how many times a request that timed out or got a 5xx response is
+// retried. The wait before retry n is backoff * 2^(n-1).
+func WithRetries(retries int, backoff time.Duration) ClientOption {
+	return func(c *Client) {
+		c.retries = retries
+		c.backoff = backoff
+	}
+}
+
+// WithBatching sets how many codes are requested at once and how many of those
+// requests may run concurrently.
+func WithBatching(batchSize, workers int) ClientOption {
+	return func(c *Client) {
+		c.batchSize = max(batchSize, 1)
+		c.workers = max(workers, 1)
+	}
+}
+
+// WithTransport sends requests through rt, e.g. to record or replay them.
+func WithTransport(rt http.RoundTripper) ClientOption {
+	return func(c *Client) {
+		c.httpClient.Transport = rt
+	}
+}
+
+// WithBaseURL sends all requests to baseURL instead of the Naver hosts, e.g. to a
+// naverfake server.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = baseURL
+	}
+}
+
 func NewClient(logger diag.Logger, opts ...ClientOption) *Client {
 	c := &Client{
 		httpClient: &http.Client{
-			Timeout: 2 * time.Second,
+			Timeout: defaultTimeout,
 		},
-		logger: logger,
+		logger:    logger,
+		retries:   defaultRetries,
+		backoff:   defaultBackoff,
+		batchSize: defaultBatchSize,
+		workers:   defaultWorkers,
 	}
 
 	for _, opt := range opts {
@@ -45,35 +107,75 @@ func NewClient(logger diag.Logger, opts ...ClientOption) *Client {
 	return c
 }
 
-func (c *Client) FetchStocks(codes []string) ([]models.Stock, error) {
+func (c *Client) Name() string {
+	return ProviderName
+}
+
+// Capabilities reports that Naver serves quotes, indices and price history.
+func (c *Client) Capabilities() quote.Capability {
+	return quote.CapAll
+}
+
+// FetchStocks fetches quotes for codes. Long lists are split into batches that are
+// fetched concurrently and merged back in the order of codes.
+func (c *Client) FetchStocks(ctx context.Context, codes []string) ([]models.Stock, error) {
+	return c.fetchBatches(ctx, endpoint(c.baseURL, naverPollingHost, naverPollingPath), codes)
+}
+
+func (c *Client) fetchBatches(ctx context.Context, baseURL string, codes []string) ([]models.Stock, error) {
 	if len(codes) == 0 {
 		return []models.Stock{}, nil
 	}
 
-	joinedCodes := strings.Join(codes, ",")
-	url := naverPollingURL + joinedCodes
+	batches := slices.Collect(slices.Chunk(codes, c.batchSize))
+	if len(batches) == 1 {
+		return c.fetchData(ctx, baseURL+strings.Join(codes, ","))
+	}
+
+	// The first failed batch cancels the others.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
-	return c.fetchData(url)
+	results := make([][]models.Stock, len(batches))
+	errs := make([]error, len(batches))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for range min(c.workers, len(batches)) {
+		wg.Go(func() {
+			for i := range jobs {
+				results[i], errs[i] = c.fetchData(ctx, baseURL+strings.Join(batches[i], ","))
+				if errs[i] != nil {
+					cancel()
+				}
+			}
+		})
+	}
+	for i := range batches {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := quote.FirstError(errs); err != nil {
+		return nil, err
+	}
+	return slices.Concat(results...), nil
 }
 
-func (c *Client) FetchIndices() ([]models.Stock, error) {
-	return c.fetchData(naverIndexURL)
+func (c *Client) FetchIndices(ctx context.Context) ([]models.Stock, error) {
+	return c.fetchData(ctx, endpoint(c.baseURL, naverPollingHost, naverIndexPath))
 }
 
-func (c *Client) fetchData(url string) ([]models.Stock, error) {
-	resp, err := c.httpClient.Get(url)
+func (c *Client) fetchData(ctx context.Context, url string) ([]models.Stock, error) {
+	body, err := c.get(ctx, url)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch data: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("naver api returned status: %d", resp.StatusCode)
+		return nil, err
 	}
 
 	var naverResp NaverResponse
-	if err := json.NewDecoder(resp.Body).Decode(&naverResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := json.Unmarshal(body, &naverResp); err != nil {
+		return nil, &quote.DecodeError{Provider: ProviderName, URL: url, Err: err}
 	}
 
 	var stocks []models.Stock
@@ -84,6 +186,77 @@ func (c *Client) fetchData(url string) ([]models.Stock, error) {
 	return stocks, nil
 }
 
+// get fetches url and returns the response body, retrying timeouts and 5xx
+// responses with exponential backoff. Errors are a *quote.NetworkError or a
+// *quote.StatusError, or the context's error once it is done.
+func (c *Client) get(ctx context.Context, url string) ([]byte, error) {
+	wait := c.backoff
+	for attempt := 0; ; attempt++ {
+		body, err := c.getOnce(ctx, url)
+		if err == nil || attempt >= c.retries || !isRetryable(err) {
+			return body, err
+		}
+
+		c.logger.Debug("Retrying %s in %s: %v", url, wait, err)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+		wait *= 2
+	}
+}
+
+func (c *Client) getOnce(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, &quote.NetworkError{Provider: ProviderName, URL: url, Err: err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &quote.StatusError{Provider: ProviderName, URL: url, StatusCode: resp.StatusCode}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, &quote.NetworkError{Provider: ProviderName, URL: url, Err: err}
+	}
+	return body, nil
+}
+
+// endpoint joins host and path, with baseURL taking the place of host when set.
+func endpoint(baseURL, host, path string) string {
+	if baseURL != "" {
+		return strings.TrimSuffix(baseURL, "/") + path
+	}
+	return host + path
+}
+
+func isRetryable(err error) bool {
+	var netErr *quote.NetworkError
+	var statusErr *quote.StatusError
+	switch {
+	case errors.As(err, &netErr):
+		return netErr.Timeout()
+	case errors.As(err, &statusErr):
+		return statusErr.Temporary()
+	default:
+		return false
+	}
+}
+
 func MapToStock(apiData NaverStockData) models.Stock {
 	price := parsePrice(apiData.ClosePrice)
 	change := parsePrice(apiData.CompareToPreviousClosePrice)
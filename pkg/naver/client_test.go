@@ -1,9 +1,20 @@
 package naver
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/ericyhkim/juga/pkg/diag"
 	"github.com/ericyhkim/juga/pkg/models"
+	"github.com/ericyhkim/juga/pkg/naver/naverfake"
+	"github.com/ericyhkim/juga/pkg/quote"
 )
 
 func TestMapToStock(t *testing.T) {
@@ -89,3 +100,223 @@ func TestIsValidCode(t *testing.T) {
 		}
 	}
 }
+
+func TestClientGet_RetriesServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"datas":[{"itemCode":"005930","stockName":"삼성전자","closePrice":"75,200"}]}`)
+	}))
+	defer srv.Close()
+
+	c := NewClient(diag.NewNopLogger(), WithRetries(2, time.Millisecond))
+	stocks, err := c.fetchData(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatalf("Expected success after retries, got %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls.Load())
+	}
+	if len(stocks) != 1 || stocks[0].Price != 75200 {
+		t.Errorf("Unexpected stocks: %+v", stocks)
+	}
+}
+
+func TestClientGet_TypedErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		switch r.URL.Path {
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "/down":
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			fmt.Fprint(w, "<html>maintenance</html>")
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(diag.NewNopLogger(), WithRetries(2, time.Millisecond))
+
+	_, err := c.fetchData(context.Background(), srv.URL+"/missing")
+	var statusErr *quote.StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected a 404 StatusError, got %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("Expected a 404 not to be retried, got %d attempts", calls.Load())
+	}
+
+	calls.Store(0)
+	_, err = c.fetchData(context.Background(), srv.URL+"/down")
+	if !errors.As(err, &statusErr) || !statusErr.Temporary() {
+		t.Errorf("Expected a temporary StatusError, got %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("Expected 3 attempts for a 502, got %d", calls.Load())
+	}
+
+	_, err = c.fetchData(context.Background(), srv.URL+"/html")
+	var decodeErr *quote.DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Errorf("Expected a DecodeError, got %v", err)
+	}
+
+	_, err = c.fetchData(context.Background(), "http://127.0.0.1:1/")
+	var netErr *quote.NetworkError
+	if !errors.As(err, &netErr) {
+		t.Errorf("Expected a NetworkError, got %v", err)
+	}
+}
+
+func TestClientGet_Cancel(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	c := NewClient(diag.NewNopLogger(), WithTimeout(10*time.Second))
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := c.fetchData(ctx, srv.URL)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected the request to abort promptly, took %s", elapsed)
+	}
+}
+
+func TestFetchBatches(t *testing.T) {
+	var inFlight, peak, calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		var datas []string
+		for _, code := range strings.Split(strings.TrimPrefix(r.URL.Path, "/"), ",") {
+			datas = append(datas, fmt.Sprintf(`{"itemCode":%q}`, code))
+		}
+		fmt.Fprintf(w, `{"datas":[%s]}`, strings.Join(datas, ","))
+	}))
+	defer srv.Close()
+
+	var codes []string
+	for i := range 65 {
+		codes = append(codes, fmt.Sprintf("%06d", i))
+	}
+
+	c := NewClient(diag.NewNopLogger(), WithBatching(20, 2))
+	stocks, err := c.fetchBatches(context.Background(), srv.URL+"/", codes)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if calls.Load() != 4 {
+		t.Errorf("Expected 4 batches, got %d", calls.Load())
+	}
+	if peak.Load() > 2 {
+		t.Errorf("Expected at most 2 concurrent requests, got %d", peak.Load())
+	}
+	if len(stocks) != len(codes) {
+		t.Fatalf("Expected %d stocks, got %d", len(codes), len(stocks))
+	}
+	for i, s := range stocks {
+		if s.Code != codes[i] {
+			t.Fatalf("Expected stock %d to be %s, got %s", i, codes[i], s.Code)
+		}
+	}
+}
+
+func TestFetchBatches_Error(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "000003") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+		fmt.Fprint(w, `{"datas":[]}`)
+	}))
+	defer srv.Close()
+
+	codes := []string{"000001", "000002", "000003", "000004"}
+	c := NewClient(diag.NewNopLogger(), WithBatching(1, 4))
+	_, err := c.fetchBatches(context.Background(), srv.URL+"/", codes)
+
+	var statusErr *quote.StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected the failed batch's StatusError, got %v", err)
+	}
+}
+
+func TestClientAgainstFake(t *testing.T) {
+	fake := naverfake.NewServer()
+	defer fake.Close()
+
+	c := NewClient(diag.NewNopLogger(), WithBaseURL(fake.URL), WithRetries(2, time.Millisecond), WithBatching(4, 2))
+
+	codes := append(fake.Codes(), "999999")
+	stocks, err := c.FetchStocks(context.Background(), codes)
+	if err != nil {
+		t.Fatalf("FetchStocks() returned error: %v", err)
+	}
+	if len(stocks) != len(codes)-1 {
+		t.Fatalf("Expected %d stocks without the unknown code, got %d", len(codes)-1, len(stocks))
+	}
+	for i, s := range stocks {
+		if s.Code != codes[i] || s.Price == 0 || s.Name == "" {
+			t.Errorf("Unexpected stock %d: %+v", i, s)
+		}
+	}
+	if got := fake.Requests(naverPollingPath); got != 3 {
+		t.Errorf("Expected 3 batched polling requests, got %d", got)
+	}
+
+	indices, err := c.FetchIndices(context.Background())
+	if err != nil || len(indices) != 2 || indices[0].Code != "KOSPI" || indices[0].Price != 2612.43 {
+		t.Errorf("Unexpected indices: %+v, %v", indices, err)
+	}
+
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(2026, 10, 16, 0, 0, 0, 0, time.Local)
+	candles, err := c.FetchCandles(context.Background(), "005930", models.IntervalDay, from, to)
+	if err != nil {
+		t.Fatalf("FetchCandles() returned error: %v", err)
+	}
+	if len(candles) != 12 || candles[len(candles)-1].Close != 71500 {
+		t.Errorf("Expected 12 candles up to a close of 71500, got %d", len(candles))
+	}
+
+	fake.FailNext(1, http.StatusServiceUnavailable)
+	if _, err := c.FetchIndices(context.Background()); err != nil {
+		t.Errorf("Expected a retry to recover from a 503, got %v", err)
+	}
+
+	fake.FailNext(3, 0)
+	var statusErr *quote.StatusError
+	if _, err := c.FetchIndices(context.Background()); !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("Expected FailNext without a status to fail with 500, got %v", err)
+	}
+}
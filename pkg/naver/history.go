@@ -0,0 +1,98 @@
+package naver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ericyhkim/juga/pkg/models"
+	"github.com/ericyhkim/juga/pkg/quote"
+)
+
+const (
+	naverCandlePath   = "/siseJson.naver?symbol=%s&requestType=1&startTime=%s&endTime=%s&timeframe=%s"
+	naverCandleLayout = "20060102"
+)
+
+// FetchCandles returns the OHLCV history of a stock between from and to (inclusive),
+// ordered from oldest to newest.
+func (c *Client) FetchCandles(ctx context.Context, code string, interval models.CandleInterval, from, to time.Time) ([]models.Candle, error) {
+	if !models.IsValidCode(code) {
+		return nil, fmt.Errorf("invalid stock code: %s", code)
+	}
+	if interval == "" {
+		interval = models.IntervalDay
+	}
+
+	path := fmt.Sprintf(naverCandlePath, code, from.Format(naverCandleLayout), to.Format(naverCandleLayout), interval)
+	url := endpoint(c.baseURL, naverAPIHost, path)
+
+	body, err := c.get(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	candles, err := ParseCandles(body)
+	if err != nil {
+		return nil, &quote.DecodeError{Provider: ProviderName, URL: url, Err: err}
+	}
+	return candles, nil
+}
+
+// ParseCandles decodes the siseJson payload. Naver serves it as a JavaScript array
+// literal whose header row uses single quotes, e.g.
+//
+//	[['날짜', '시가', '고가', '저가', '종가', '거래량', '외국인소진율'],
+//	["20240102", 78200, 79800, 78200, 79600, 17142847, 53.07]]
+func ParseCandles(body []byte) ([]models.Candle, error) {
+	normalized := bytes.ReplaceAll(bytes.TrimSpace(body), []byte("'"), []byte(`"`))
+	if len(normalized) == 0 {
+		return []models.Candle{}, nil
+	}
+
+	var rows [][]interface{}
+	if err := json.Unmarshal(normalized, &rows); err != nil {
+		return nil, fmt.Errorf("failed to decode candles: %w", err)
+	}
+
+	candles := make([]models.Candle, 0, len(rows))
+	for _, row := range rows {
+		if len(row) < 6 {
+			continue
+		}
+
+		dateStr, ok := row[0].(string)
+		if !ok {
+			continue
+		}
+		date, err := time.ParseInLocation(naverCandleLayout, dateStr, time.Local)
+		if err != nil {
+			// The header row ('날짜', ...) lands here as well.
+			continue
+		}
+
+		candles = append(candles, models.Candle{
+			Date:   date,
+			Open:   toFloat(row[1]),
+			High:   toFloat(row[2]),
+			Low:    toFloat(row[3]),
+			Close:  toFloat(row[4]),
+			Volume: toFloat(row[5]),
+		})
+	}
+
+	return candles, nil
+}
+
+func toFloat(v interface{}) float64 {
+	switch n := v.(type) {
+	case float64:
+		return n
+	case string:
+		return parsePrice(n)
+	default:
+		return 0.0
+	}
+}
@@ -0,0 +1,47 @@
+package naver
+
+import (
+	"testing"
+)
+
+func TestParseCandles(t *testing.T) {
+	raw := []byte(`
+ [['날짜', '시가', '고가', '저가', '종가', '거래량', '외국인소진율'],
+["20240102", 78200, 79800, 78200, 79600, 17142847, 53.07],
+["20240103", 78500, 78800, 77000, 77000, 21753644, 53.01]
+]
+`)
+
+	candles, err := ParseCandles(raw)
+	if err != nil {
+		t.Fatalf("ParseCandles() returned error: %v", err)
+	}
+
+	if len(candles) != 2 {
+		t.Fatalf("Expected 2 candles, got %d", len(candles))
+	}
+
+	first := candles[0]
+	if first.Date.Format("2006-01-02") != "2024-01-02" {
+		t.Errorf("Expected date 2024-01-02, got %s", first.Date.Format("2006-01-02"))
+	}
+	if first.Open != 78200 || first.High != 79800 || first.Low != 78200 || first.Close != 79600 {
+		t.Errorf("Unexpected OHLC: %+v", first)
+	}
+	if first.Volume != 17142847 {
+		t.Errorf("Expected Volume 17142847, got %f", first.Volume)
+	}
+	if candles[1].Close != 77000 {
+		t.Errorf("Expected second Close 77000, got %f", candles[1].Close)
+	}
+}
+
+func TestParseCandles_Empty(t *testing.T) {
+	candles, err := ParseCandles([]byte("\n [['날짜', '시가', '고가', '저가', '종가', '거래량', '외국인소진율']]\n"))
+	if err != nil {
+		t.Fatalf("ParseCandles() returned error: %v", err)
+	}
+	if len(candles) != 0 {
+		t.Errorf("Expected 0 candles, got %d", len(candles))
+	}
+}
@@ -11,6 +11,7 @@ type NaverResponse struct {
 type NaverStockData struct {
 	ItemCode                    string                 `json:"itemCode"`
 	StockName                   string                 `json:"stockName"`
+	StockNameEng                string                 `json:"stockNameEng"`
 	ClosePrice                  string                 `json:"closePrice"`
 	CompareToPreviousClosePrice string                 `json:"compareToPreviousClosePrice"`
 	FluctuationsRatio           string                 `json:"fluctuationsRatio"`
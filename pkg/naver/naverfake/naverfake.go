@@ -0,0 +1,211 @@
+// Package naverfake serves recorded Naver Finance responses, so that the naver
+// package and the CLI can run without network access. Point a naver.Client at it
+// with naver.WithBaseURL, a naver.Scraper with naver.WithScraperBaseURL, or the
+// whole CLI with JUGA_NAVER_BASE_URL.
+//
+// The recordings cover a handful of KOSPI/KOSDAQ stocks and ETFs (see Codes) as of
+// the close of 2026-10-16, plus the daily history of 005930.
+package naverfake
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+//go:embed fixtures
+var fixtures embed.FS
+
+// Server is a running fake. Requests can be made to fail with FailNext.
+type Server struct {
+	*httptest.Server
+	handler *Handler
+}
+
+// NewServer starts a fake on a local port. Close it when done.
+func NewServer() *Server {
+	h := NewHandler()
+	return &Server{Server: httptest.NewServer(h), handler: h}
+}
+
+// Codes returns the codes of the recorded stocks.
+func (s *Server) Codes() []string {
+	return s.handler.Codes()
+}
+
+// FailNext makes the next n requests fail with status, or 500 if status is not an
+// HTTP status code.
+func (s *Server) FailNext(n, status int) {
+	s.handler.FailNext(n, status)
+}
+
+// Requests returns how many requests were made to paths starting with prefix.
+func (s *Server) Requests(prefix string) int {
+	return s.handler.Requests(prefix)
+}
+
+// Handler serves the recorded responses under the paths of the Naver hosts.
+type Handler struct {
+	mux    *http.ServeMux
+	quotes map[string]json.RawMessage
+	codes  []string
+
+	mu       sync.Mutex
+	failures int
+	status   int
+	paths    []string
+}
+
+// NewHandler returns a handler serving the recorded responses.
+func NewHandler() *Handler {
+	h := &Handler{mux: http.NewServeMux(), quotes: make(map[string]json.RawMessage)}
+
+	var polling struct {
+		Datas []json.RawMessage `json:"datas"`
+	}
+	if err := json.Unmarshal(mustRead("polling.json"), &polling); err != nil {
+		panic(fmt.Sprintf("naverfake: invalid polling fixture: %v", err))
+	}
+	for _, data := range polling.Datas {
+		var item struct {
+			ItemCode string `json:"itemCode"`
+		}
+		if err := json.Unmarshal(data, &item); err != nil {
+			panic(fmt.Sprintf("naverfake: invalid polling fixture: %v", err))
+		}
+		h.quotes[item.ItemCode] = data
+		h.codes = append(h.codes, item.ItemCode)
+	}
+
+	h.mux.HandleFunc("/api/realtime/domestic/stock/{codes}", h.serveQuotes)
+	h.mux.HandleFunc("/api/realtime/domestic/index/{codes}", serveFixture("index.json", "application/json;charset=UTF-8"))
+	h.mux.HandleFunc("/siseJson.naver", serveCandles)
+	h.mux.HandleFunc("/sise/sise_market_sum.naver", serveMarketSum)
+	h.mux.HandleFunc("/api/sise/etfItemList.nhn", serveFixture("etf.json", "application/json;charset=EUC-KR"))
+	return h
+}
+
+// Codes returns the codes of the recorded stocks.
+func (h *Handler) Codes() []string {
+	return append([]string(nil), h.codes...)
+}
+
+// FailNext makes the next n requests fail with status, or 500 if status is not an
+// HTTP status code.
+func (h *Handler) FailNext(n, status int) {
+	if status < 100 || status > 999 {
+		status = http.StatusInternalServerError
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.failures, h.status = n, status
+}
+
+// Requests returns how many requests were made to paths starting with prefix.
+func (h *Handler) Requests(prefix string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	n := 0
+	for _, p := range h.paths {
+		if strings.HasPrefix(p, prefix) {
+			n++
+		}
+	}
+	return n
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	h.paths = append(h.paths, r.URL.Path)
+	fail := h.failures > 0
+	if fail {
+		h.failures--
+	}
+	status := h.status
+	h.mu.Unlock()
+
+	if fail {
+		w.WriteHeader(status)
+		return
+	}
+	h.mux.ServeHTTP(w, r)
+}
+
+// serveQuotes answers a polling request with the recorded quotes of the requested
+// codes, in request order. Unknown codes are left out, like Naver does.
+func (h *Handler) serveQuotes(w http.ResponseWriter, r *http.Request) {
+	datas := []json.RawMessage{}
+	for _, code := range strings.Split(r.PathValue("codes"), ",") {
+		if data, ok := h.quotes[code]; ok {
+			datas = append(datas, data)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	json.NewEncoder(w).Encode(map[string]any{
+		"pollingInterval": 7000,
+		"datas":           datas,
+		"time":            "20261016161009",
+	})
+}
+
+// candleHeader is the header row siseJson starts with.
+const candleHeader = "[['날짜', '시가', '고가', '저가', '종가', '거래량', '외국인소진율']"
+
+// serveCandles answers a siseJson request with the recorded rows between startTime
+// and endTime. Stocks without a recording get the header row only.
+func serveCandles(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	from, to := q.Get("startTime"), q.Get("endTime")
+
+	w.Header().Set("Content-Type", "text/plain;charset=UTF-8")
+	fmt.Fprintf(w, "\n %s", candleHeader)
+
+	body, _ := fixtures.ReadFile("fixtures/candles_" + q.Get("symbol") + ".txt")
+	for _, line := range bytes.Split(body, []byte("\n")) {
+		row := bytes.TrimSuffix(bytes.TrimSpace(line), []byte(","))
+		if !bytes.HasPrefix(row, []byte(`["`)) || len(row) < 11 {
+			continue
+		}
+		if date := string(row[2:10]); date >= from && date <= to {
+			fmt.Fprintf(w, ",\n%s", row)
+		}
+	}
+	fmt.Fprint(w, "\n]\n")
+}
+
+// serveMarketSum answers a sise_market_sum request with the recorded EUC-KR page.
+// Pages past the recording are served without rows, which ends a scrape.
+func serveMarketSum(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	body, err := fixtures.ReadFile(fmt.Sprintf("fixtures/market_sum_%s_%s.html", q.Get("sosok"), q.Get("page")))
+	if err != nil {
+		body = []byte(`<html><body><table class="type_2"><tbody></tbody></table></body></html>`)
+	}
+
+	w.Header().Set("Content-Type", "text/html;charset=EUC-KR")
+	w.Write(body)
+}
+
+func serveFixture(name, contentType string) http.HandlerFunc {
+	body := mustRead(name)
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.Write(body)
+	}
+}
+
+func mustRead(name string) []byte {
+	body, err := fixtures.ReadFile("fixtures/" + name)
+	if err != nil {
+		panic(fmt.Sprintf("naverfake: missing fixture %s: %v", name, err))
+	}
+	return body
+}
@@ -6,7 +6,9 @@ import (
 	"io"
 	"net/http"
 	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -15,48 +17,89 @@ import (
 )
 
 const (
-	kospiURL        = "https://finance.naver.com/sise/sise_market_sum.naver?sosok=0&page=%d"
-	kosdaqURL       = "https://finance.naver.com/sise/sise_market_sum.naver?sosok=1&page=%d"
-	etfAPIURL       = "https://finance.naver.com/api/sise/etfItemList.nhn?etfType=0&targetColumn=market_sum&sortOrder=desc"
+	kospiPath       = "/sise/sise_market_sum.naver?sosok=0&page=%d"
+	kosdaqPath      = "/sise/sise_market_sum.naver?sosok=1&page=%d"
+	etfAPIPath      = "/api/sise/etfItemList.nhn?etfType=0&targetColumn=market_sum&sortOrder=desc"
 	defaultMaxPages = 40
+	// englishNameBatchSize is the number of codes per polling request when looking
+	// up English names, within the limit the Client batches quotes by.
+	englishNameBatchSize = defaultBatchSize
 )
 
 type Scraper struct {
-	client *http.Client
-	re     *regexp.Regexp
-	pgRe   *regexp.Regexp
-	logger diag.Logger
+	client  *http.Client
+	re      *regexp.Regexp
+	capRe   *regexp.Regexp
+	pgRe    *regexp.Regexp
+	logger  diag.Logger
+	baseURL string
 }
 
-func NewScraper(timeout time.Duration, logger diag.Logger) *Scraper {
-	return &Scraper{
+type ScraperOption func(*Scraper)
+
+// WithScraperTransport sends requests through rt, e.g. to record or replay them.
+func WithScraperTransport(rt http.RoundTripper) ScraperOption {
+	return func(s *Scraper) {
+		s.client.Transport = rt
+	}
+}
+
+// WithScraperBaseURL sends all requests to baseURL instead of the Naver hosts, e.g.
+// to a naverfake server.
+func WithScraperBaseURL(baseURL string) ScraperOption {
+	return func(s *Scraper) {
+		s.baseURL = baseURL
+	}
+}
+
+// scrapedTicker is a listing row together with its market capitalization (in 100M
+// KRW), used to rank tickers across markets.
+type scrapedTicker struct {
+	ticker    models.Ticker
+	marketCap float64
+}
+
+// marketCapColumn is the position of 시가총액 among the plain numeric cells of a
+// sise_market_sum row: 현재가, 액면가, 시가총액, 상장주식수, ...
+const marketCapColumn = 2
+
+func NewScraper(timeout time.Duration, logger diag.Logger, opts ...ScraperOption) *Scraper {
+	s := &Scraper{
 		client: &http.Client{Timeout: timeout},
 		re:     regexp.MustCompile(`href="/item/main.naver\?code=([A-Z0-9]+)" class="tltle">([^<]+)</a>`),
+		capRe:  regexp.MustCompile(`<td class="number">([\d,]+)</td>`),
 		pgRe:   regexp.MustCompile(`class="pgRR">\s*<a href=".*?page=(\d+)`),
 		logger: logger,
 	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
 
 type etfResponse struct {
 	Result struct {
 		EtfItemList []struct {
-			ItemCode string `json:"itemcode"`
-			ItemName string `json:"itemname"`
+			ItemCode  string  `json:"itemcode"`
+			ItemName  string  `json:"itemname"`
+			MarketSum float64 `json:"marketSum"`
 		} `json:"etfItemList"`
 	} `json:"result"`
 }
 
 func (s *Scraper) ScrapeAll() ([]models.Ticker, error) {
 	var (
-		tickers []models.Ticker
+		tickers []scrapedTicker
 		mu      sync.Mutex
 		wg      sync.WaitGroup
 	)
 
-	scrapeMarket := func(urlFmt, marketName string) {
+	scrapeMarket := func(pathFmt, marketName string) {
 		defer wg.Done()
 
-		firstURL := fmt.Sprintf(urlFmt, 1)
+		firstURL := endpoint(s.baseURL, naverFinanceHost, fmt.Sprintf(pathFmt, 1))
 		resp, err := s.client.Get(firstURL)
 		if err != nil {
 			return
@@ -73,15 +116,8 @@ func (s *Scraper) ScrapeAll() ([]models.Ticker, error) {
 		}
 		body := []byte(decodedBody)
 
-		matches := s.re.FindAllSubmatch(body, -1)
 		mu.Lock()
-		for _, m := range matches {
-			tickers = append(tickers, models.Ticker{
-				Code:   string(m[1]),
-				Name:   string(m[2]),
-				Market: marketName,
-			})
-		}
+		tickers = append(tickers, s.parseMarketSumPage(decodedBody, marketName)...)
 		mu.Unlock()
 
 		lastPage := defaultMaxPages
@@ -92,7 +128,7 @@ func (s *Scraper) ScrapeAll() ([]models.Ticker, error) {
 		}
 
 		for page := 2; page <= lastPage; page++ {
-			url := fmt.Sprintf(urlFmt, page)
+			url := endpoint(s.baseURL, naverFinanceHost, fmt.Sprintf(pathFmt, page))
 			time.Sleep(50 * time.Millisecond)
 
 			resp, err := s.client.Get(url)
@@ -110,21 +146,14 @@ func (s *Scraper) ScrapeAll() ([]models.Ticker, error) {
 			if err != nil {
 				break
 			}
-			body := []byte(decodedBody)
 
-			matches := s.re.FindAllSubmatch(body, -1)
-			if len(matches) == 0 {
+			rows := s.parseMarketSumPage(decodedBody, marketName)
+			if len(rows) == 0 {
 				break
 			}
 
 			mu.Lock()
-			for _, m := range matches {
-				tickers = append(tickers, models.Ticker{
-					Code:   string(m[1]),
-					Name:   string(m[2]),
-					Market: marketName,
-				})
-			}
+			tickers = append(tickers, rows...)
 			mu.Unlock()
 		}
 	}
@@ -132,7 +161,7 @@ func (s *Scraper) ScrapeAll() ([]models.Ticker, error) {
 	scrapeETF := func() {
 		defer wg.Done()
 
-		resp, err := s.client.Get(etfAPIURL)
+		resp, err := s.client.Get(endpoint(s.baseURL, naverFinanceHost, etfAPIPath))
 		if err != nil {
 			return
 		}
@@ -155,18 +184,22 @@ func (s *Scraper) ScrapeAll() ([]models.Ticker, error) {
 
 		mu.Lock()
 		for _, item := range result.Result.EtfItemList {
-			tickers = append(tickers, models.Ticker{
-				Code:   item.ItemCode,
-				Name:   item.ItemName,
-				Market: "KOSPI",
+			tickers = append(tickers, scrapedTicker{
+				ticker: models.Ticker{
+					Code:   item.ItemCode,
+					Name:   item.ItemName,
+					Market: "KOSPI",
+					ETF:    true,
+				},
+				marketCap: item.MarketSum,
 			})
 		}
 		mu.Unlock()
 	}
 
 	wg.Add(3)
-	go scrapeMarket(kospiURL, "KOSPI")
-	go scrapeMarket(kosdaqURL, "KOSDAQ")
+	go scrapeMarket(kospiPath, "KOSPI")
+	go scrapeMarket(kosdaqPath, "KOSDAQ")
 	go scrapeETF()
 
 	wg.Wait()
@@ -175,14 +208,126 @@ func (s *Scraper) ScrapeAll() ([]models.Ticker, error) {
 		return nil, fmt.Errorf("scraped 0 tickers; network or parsing error likely")
 	}
 
-	unique := make([]models.Ticker, 0, len(tickers))
-	seen := make(map[string]bool)
-	for _, t := range tickers {
-		if !seen[t.Code] {
-			seen[t.Code] = true
-			unique = append(unique, t)
+	ranked := rankByMarketCap(dedupTickers(tickers))
+	s.attachEnglishNames(ranked)
+
+	return ranked, nil
+}
+
+// dedupTickers keeps one row per code. ETFs can also appear in the KOSPI listing;
+// their ETF row wins whichever order the listings were scraped in.
+func dedupTickers(rows []scrapedTicker) []scrapedTicker {
+	unique := make([]scrapedTicker, 0, len(rows))
+	index := make(map[string]int, len(rows))
+	for _, row := range rows {
+		i, ok := index[row.ticker.Code]
+		if !ok {
+			index[row.ticker.Code] = len(unique)
+			unique = append(unique, row)
+			continue
 		}
+		if row.ticker.ETF && !unique[i].ticker.ETF {
+			unique[i] = row
+		}
+	}
+	return unique
+}
+
+// rankByMarketCap orders tickers by market capitalization, largest first, and sets
+// Ticker.Rank accordingly. Tickers without a known cap keep their scraped order
+// after all others.
+func rankByMarketCap(rows []scrapedTicker) []models.Ticker {
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].marketCap > rows[j].marketCap
+	})
+
+	tickers := make([]models.Ticker, len(rows))
+	for i, row := range rows {
+		tickers[i] = row.ticker
+		tickers[i].Rank = i + 1
 	}
+	return tickers
+}
+
+// parseMarketSumPage extracts the listings of one sise_market_sum page. The market cap
+// is read from the row's numeric cells and left at zero if the layout is unexpected.
+func (s *Scraper) parseMarketSumPage(html, market string) []scrapedTicker {
+	var rows []scrapedTicker
+	for _, row := range strings.Split(html, "<tr") {
+		m := s.re.FindStringSubmatch(row)
+		if m == nil {
+			continue
+		}
+
+		var marketCap float64
+		if cells := s.capRe.FindAllStringSubmatch(row, -1); len(cells) > marketCapColumn {
+			marketCap, _ = strconv.ParseFloat(strings.ReplaceAll(cells[marketCapColumn][1], ",", ""), 64)
+		}
 
-	return unique, nil
+		rows = append(rows, scrapedTicker{
+			ticker: models.Ticker{
+				Code:   m[1],
+				Name:   m[2],
+				Market: market,
+			},
+			marketCap: marketCap,
+		})
+	}
+	return rows
+}
+
+// attachEnglishNames fills in Ticker.EnglishName from the polling API. Failures only
+// cost searchability by English name, so they are logged and otherwise ignored.
+func (s *Scraper) attachEnglishNames(tickers []models.Ticker) {
+	names := make(map[string]string, len(tickers))
+
+	for start := 0; start < len(tickers); start += englishNameBatchSize {
+		end := min(start+englishNameBatchSize, len(tickers))
+
+		codes := make([]string, 0, end-start)
+		for _, t := range tickers[start:end] {
+			codes = append(codes, t.Code)
+		}
+
+		resp, err := s.client.Get(endpoint(s.baseURL, naverPollingHost, naverPollingPath) + strings.Join(codes, ","))
+		if err != nil {
+			s.logger.Warn("Failed to fetch English names: %v", err)
+			return
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			s.logger.Warn("Failed to read English names: %v", err)
+			return
+		}
+
+		batch, err := ParseEnglishNames(body)
+		if err != nil {
+			s.logger.Warn("Failed to parse English names: %v", err)
+			return
+		}
+		for code, name := range batch {
+			names[code] = name
+		}
+	}
+
+	for i := range tickers {
+		tickers[i].EnglishName = names[tickers[i].Code]
+	}
+}
+
+// ParseEnglishNames extracts code → English name pairs from a polling API response.
+func ParseEnglishNames(body []byte) (map[string]string, error) {
+	var resp NaverResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	names := make(map[string]string, len(resp.Datas))
+	for _, d := range resp.Datas {
+		if name := strings.TrimSpace(d.StockNameEng); name != "" {
+			names[d.ItemCode] = name
+		}
+	}
+	return names, nil
 }
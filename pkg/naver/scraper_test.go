@@ -0,0 +1,136 @@
+package naver
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ericyhkim/juga/pkg/diag"
+	"github.com/ericyhkim/juga/pkg/models"
+	"github.com/ericyhkim/juga/pkg/naver/naverfake"
+)
+
+func TestParseEnglishNames(t *testing.T) {
+	body := []byte(`{"datas":[
+		{"itemCode":"005930","stockName":"삼성전자","stockNameEng":"SamsungElec"},
+		{"itemCode":"000660","stockName":"SK하이닉스","stockNameEng":" SK hynix "},
+		{"itemCode":"123456","stockName":"이름만","stockNameEng":""}
+	]}`)
+
+	names, err := ParseEnglishNames(body)
+	if err != nil {
+		t.Fatalf("ParseEnglishNames() returned error: %v", err)
+	}
+
+	if names["005930"] != "SamsungElec" || names["000660"] != "SK hynix" {
+		t.Errorf("Unexpected names: %v", names)
+	}
+	if _, ok := names["123456"]; ok {
+		t.Errorf("Expected empty English names to be skipped")
+	}
+}
+
+func TestParseMarketSumPage(t *testing.T) {
+	html := `<table class="type_2"><tbody>
+<tr onMouseOver="mouseOver(this)">
+	<td class="no">1</td>
+	<td><a href="/item/main.naver?code=005930" class="tltle">삼성전자</a></td>
+	<td class="number">71,500</td>
+	<td class="number"><span class="tah p11 nv01">500</span></td>
+	<td class="number"><span class="tah p11 nv01">-0.69%</span></td>
+	<td class="number">100</td>
+	<td class="number">4,268,417</td>
+	<td class="number">5,969,783</td>
+</tr>
+<tr><td class="blank_08" colspan="10"></td></tr>
+<tr onMouseOver="mouseOver(this)">
+	<td class="no">2</td>
+	<td><a href="/item/main.naver?code=000660" class="tltle">SK하이닉스</a></td>
+	<td class="number">170,000</td>
+	<td class="number"><span class="tah p11 red02">1,000</span></td>
+	<td class="number"><span class="tah p11 red02">+0.59%</span></td>
+	<td class="number">5,000</td>
+	<td class="number">1,237,610</td>
+	<td class="number">728,002</td>
+</tr>
+</tbody></table>`
+
+	s := NewScraper(0, nil)
+	rows := s.parseMarketSumPage(html, "KOSPI")
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d", len(rows))
+	}
+	if rows[0].ticker.Code != "005930" || rows[0].marketCap != 4268417 {
+		t.Errorf("Unexpected first row: %+v", rows[0])
+	}
+	if rows[1].ticker.Name != "SK하이닉스" || rows[1].marketCap != 1237610 {
+		t.Errorf("Unexpected second row: %+v", rows[1])
+	}
+}
+
+func TestDedupTickers_PrefersETFRows(t *testing.T) {
+	kospi := scrapedTicker{ticker: models.Ticker{Code: "069500", Name: "KODEX 200", Market: "KOSPI"}, marketCap: 100}
+	etf := scrapedTicker{ticker: models.Ticker{Code: "069500", Name: "KODEX 200", Market: "KOSPI", ETF: true}, marketCap: 100}
+	other := scrapedTicker{ticker: models.Ticker{Code: "005930", Name: "삼성전자", Market: "KOSPI"}}
+
+	for _, rows := range [][]scrapedTicker{{kospi, other, etf}, {etf, other, kospi}} {
+		unique := dedupTickers(rows)
+		if len(unique) != 2 {
+			t.Fatalf("Expected 2 tickers, got %+v", unique)
+		}
+		if !unique[0].ticker.ETF || unique[1].ticker.Code != "005930" {
+			t.Errorf("Expected the ETF row to be kept in first position, got %+v", unique)
+		}
+	}
+}
+
+func TestRankByMarketCap(t *testing.T) {
+	rows := []scrapedTicker{
+		{ticker: models.Ticker{Code: "A"}, marketCap: 10},
+		{ticker: models.Ticker{Code: "B"}},
+		{ticker: models.Ticker{Code: "C"}, marketCap: 300},
+		{ticker: models.Ticker{Code: "D"}},
+	}
+
+	var order []string
+	for _, tk := range rankByMarketCap(rows) {
+		order = append(order, fmt.Sprintf("%s%d", tk.Code, tk.Rank))
+	}
+	if got := strings.Join(order, " "); got != "C1 A2 B3 D4" {
+		t.Errorf("Unexpected ranking: %s", got)
+	}
+}
+
+func TestScrapeAllAgainstFake(t *testing.T) {
+	fake := naverfake.NewServer()
+	defer fake.Close()
+
+	s := NewScraper(5*time.Second, diag.NewNopLogger(), WithScraperBaseURL(fake.URL))
+	tickers, err := s.ScrapeAll()
+	if err != nil {
+		t.Fatalf("ScrapeAll() returned error: %v", err)
+	}
+
+	if len(tickers) != len(fake.Codes()) {
+		t.Fatalf("Expected %d tickers, got %d: %+v", len(fake.Codes()), len(tickers), tickers)
+	}
+
+	byCode := make(map[string]models.Ticker)
+	for _, tk := range tickers {
+		byCode[tk.Code] = tk
+	}
+
+	if samsung := byCode["005930"]; samsung.Name != "삼성전자" || samsung.Market != "KOSPI" || samsung.Rank != 1 || samsung.EnglishName != "SamsungElec" {
+		t.Errorf("Unexpected ticker for 005930: %+v", samsung)
+	}
+	if ecopro := byCode["086520"]; ecopro.Market != "KOSDAQ" || ecopro.Name != "에코프로" {
+		t.Errorf("Expected 에코프로 to be listed on KOSDAQ from the EUC-KR page, got %+v", ecopro)
+	}
+	if kodex := byCode["069500"]; !kodex.ETF || kodex.Name != "KODEX 200" {
+		t.Errorf("Expected KODEX 200 to be an ETF, got %+v", kodex)
+	}
+	if naver := byCode["035420"]; naver.Name != "NAVER" {
+		t.Errorf("Expected the second KOSPI page to be scraped, got %+v", naver)
+	}
+}
@@ -0,0 +1,81 @@
+package quote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// NetworkError is a request that got no response, e.g. because of a DNS failure, a
+// refused connection or a timeout.
+type NetworkError struct {
+	Provider string
+	URL      string
+	Err      error
+}
+
+func (e *NetworkError) Error() string {
+	return fmt.Sprintf("network error: %v", e.Err)
+}
+
+func (e *NetworkError) Unwrap() error {
+	return e.Err
+}
+
+// Timeout reports whether the request timed out.
+func (e *NetworkError) Timeout() bool {
+	var netErr net.Error
+	return errors.As(e.Err, &netErr) && netErr.Timeout()
+}
+
+// StatusError is a response with a status other than 200 OK.
+type StatusError struct {
+	Provider   string
+	URL        string
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s api returned status: %d %s", e.Provider, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// Temporary reports whether the server failed in a way worth retrying.
+func (e *StatusError) Temporary() bool {
+	return e.StatusCode >= 500
+}
+
+// DecodeError is a response body that could not be parsed, usually because the
+// API changed.
+type DecodeError struct {
+	Provider string
+	URL      string
+	Err      error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("failed to decode %s response: %v", e.Provider, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// FirstError returns the error that failed a set of concurrent requests, preferring
+// it over the cancellations it caused in the others.
+func FirstError(errs []error) error {
+	var canceled error
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, context.Canceled) {
+			return err
+		}
+		if canceled == nil {
+			canceled = err
+		}
+	}
+	return canceled
+}
@@ -0,0 +1,95 @@
+package quote
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ericyhkim/juga/pkg/diag"
+	"github.com/ericyhkim/juga/pkg/models"
+)
+
+// Failover serves each request from the first provider with the capability for it,
+// moving on to the next one when a provider fails.
+type Failover struct {
+	providers []Provider
+	logger    diag.Logger
+}
+
+// NewFailover returns a provider trying providers in order, primary first.
+func NewFailover(logger diag.Logger, providers ...Provider) *Failover {
+	return &Failover{providers: providers, logger: logger}
+}
+
+func (f *Failover) Name() string {
+	names := make([]string, 0, len(f.providers))
+	for _, p := range f.providers {
+		names = append(names, p.Name())
+	}
+	return strings.Join(names, ",")
+}
+
+// Capabilities is the union of the capabilities of all providers.
+func (f *Failover) Capabilities() Capability {
+	var c Capability
+	for _, p := range f.providers {
+		c |= p.Capabilities()
+	}
+	return c
+}
+
+func (f *Failover) FetchStocks(ctx context.Context, codes []string) ([]models.Stock, error) {
+	return try(ctx, f, CapQuotes, func(p Provider) ([]models.Stock, error) {
+		return p.FetchStocks(ctx, codes)
+	})
+}
+
+func (f *Failover) FetchIndices(ctx context.Context) ([]models.Stock, error) {
+	return try(ctx, f, CapIndices, func(p Provider) ([]models.Stock, error) {
+		return p.FetchIndices(ctx)
+	})
+}
+
+func (f *Failover) FetchCandles(ctx context.Context, code string, interval models.CandleInterval, from, to time.Time) ([]models.Candle, error) {
+	return try(ctx, f, CapCandles, func(p Provider) ([]models.Candle, error) {
+		return p.FetchCandles(ctx, code, interval, from, to)
+	})
+}
+
+// try runs fetch against the providers with capability until one succeeds. When all
+// of them fail, the error of the first one is returned and the others are logged.
+// Cancellation stops at once.
+func try[T any](ctx context.Context, f *Failover, capability Capability, fetch func(Provider) (T, error)) (T, error) {
+	var zero T
+	var firstErr error
+	var failed string
+
+	for _, p := range f.providers {
+		if !p.Capabilities().Has(capability) {
+			continue
+		}
+
+		result, err := fetch(p)
+		if err == nil {
+			if firstErr != nil {
+				f.logger.Debug("Quote provider %s failed, used %s instead: %v", failed, p.Name(), firstErr)
+			}
+			return result, nil
+		}
+		if ctx.Err() != nil {
+			return zero, ctx.Err()
+		}
+
+		if firstErr == nil {
+			firstErr, failed = err, p.Name()
+		} else {
+			f.logger.Debug("Quote provider %s failed too: %v", p.Name(), err)
+		}
+	}
+
+	if firstErr == nil {
+		return zero, fmt.Errorf("%s: %w", capability, ErrUnsupported)
+	}
+	return zero, firstErr
+}
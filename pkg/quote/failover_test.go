@@ -0,0 +1,117 @@
+package quote
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ericyhkim/juga/pkg/diag"
+	"github.com/ericyhkim/juga/pkg/models"
+)
+
+type stubProvider struct {
+	name  string
+	caps  Capability
+	err   error
+	calls int
+}
+
+func (p *stubProvider) Name() string             { return p.name }
+func (p *stubProvider) Capabilities() Capability { return p.caps }
+
+func (p *stubProvider) FetchStocks(ctx context.Context, codes []string) ([]models.Stock, error) {
+	p.calls++
+	if p.err != nil {
+		return nil, p.err
+	}
+	return []models.Stock{{Code: codes[0], Name: p.name}}, nil
+}
+
+func (p *stubProvider) FetchIndices(ctx context.Context) ([]models.Stock, error) {
+	p.calls++
+	if p.err != nil {
+		return nil, p.err
+	}
+	return []models.Stock{{Code: "KOSPI", Name: p.name}}, nil
+}
+
+func (p *stubProvider) FetchCandles(ctx context.Context, code string, interval models.CandleInterval, from, to time.Time) ([]models.Candle, error) {
+	p.calls++
+	return nil, p.err
+}
+
+func TestFailover_FallsBackOnError(t *testing.T) {
+	primaryErr := &StatusError{Provider: "primary", StatusCode: 500}
+	primary := &stubProvider{name: "primary", caps: CapAll, err: primaryErr}
+	backup := &stubProvider{name: "backup", caps: CapQuotes}
+	f := NewFailover(diag.NewNopLogger(), primary, backup)
+
+	stocks, err := f.FetchStocks(context.Background(), []string{"005930"})
+	if err != nil {
+		t.Fatalf("Expected the backup to answer, got %v", err)
+	}
+	if len(stocks) != 1 || stocks[0].Name != "backup" {
+		t.Errorf("Expected a quote from the backup, got %+v", stocks)
+	}
+
+	// The backup cannot serve indices, so the primary's error is reported.
+	_, err = f.FetchIndices(context.Background())
+	if !errors.Is(err, primaryErr) {
+		t.Errorf("Expected the primary's error, got %v", err)
+	}
+	if backup.calls != 1 {
+		t.Errorf("Expected the backup to be skipped for indices, got %d calls", backup.calls)
+	}
+}
+
+func TestFailover_PrimaryFirst(t *testing.T) {
+	primary := &stubProvider{name: "primary", caps: CapAll}
+	backup := &stubProvider{name: "backup", caps: CapAll}
+	f := NewFailover(diag.NewNopLogger(), primary, backup)
+
+	stocks, err := f.FetchStocks(context.Background(), []string{"005930"})
+	if err != nil || stocks[0].Name != "primary" || backup.calls != 0 {
+		t.Errorf("Expected only the primary to be asked, got %+v, %v (backup calls %d)", stocks, err, backup.calls)
+	}
+	if f.Capabilities() != CapAll || f.Name() != "primary,backup" {
+		t.Errorf("Unexpected failover description: %s (%s)", f.Name(), f.Capabilities())
+	}
+}
+
+func TestFailover_Unsupported(t *testing.T) {
+	f := NewFailover(diag.NewNopLogger(), &stubProvider{name: "quotes-only", caps: CapQuotes})
+
+	_, err := f.FetchCandles(context.Background(), "005930", models.IntervalDay, time.Now(), time.Now())
+	if !errors.Is(err, ErrUnsupported) {
+		t.Errorf("Expected ErrUnsupported, got %v", err)
+	}
+}
+
+func TestFailover_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	primary := &stubProvider{name: "primary", caps: CapAll, err: context.Canceled}
+	backup := &stubProvider{name: "backup", caps: CapAll}
+	f := NewFailover(diag.NewNopLogger(), primary, backup)
+
+	_, err := f.FetchStocks(ctx, []string{"005930"})
+	if !errors.Is(err, context.Canceled) || backup.calls != 0 {
+		t.Errorf("Expected cancellation without failover, got %v (backup calls %d)", err, backup.calls)
+	}
+}
+
+func TestCapabilityString(t *testing.T) {
+	tests := map[Capability]string{
+		0:                      "none",
+		CapQuotes:              "quotes",
+		CapQuotes | CapCandles: "quotes,candles",
+		CapAll:                 "quotes,indices,candles",
+	}
+	for c, want := range tests {
+		if got := c.String(); got != want {
+			t.Errorf("Capability(%d).String() = %q; want %q", c, got, want)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Package quote defines the interface shared by the market data backends and a
+// provider that fails over between them.
+package quote
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ericyhkim/juga/pkg/models"
+)
+
+// ErrUnsupported is returned for requests a provider has no capability for.
+var ErrUnsupported = errors.New("not supported by the quote provider")
+
+// Capability is a set of request kinds a provider can serve.
+type Capability uint8
+
+const (
+	CapQuotes Capability = 1 << iota
+	CapIndices
+	CapCandles
+
+	CapAll = CapQuotes | CapIndices | CapCandles
+)
+
+// Has reports whether c includes every capability of other.
+func (c Capability) Has(other Capability) bool {
+	return c&other == other
+}
+
+func (c Capability) String() string {
+	var names []string
+	for _, cap := range []struct {
+		flag Capability
+		name string
+	}{
+		{CapQuotes, "quotes"},
+		{CapIndices, "indices"},
+		{CapCandles, "candles"},
+	} {
+		if c.Has(cap.flag) {
+			names = append(names, cap.name)
+		}
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, ",")
+}
+
+// Provider is a source of market data. Methods outside of Capabilities return
+// ErrUnsupported.
+type Provider interface {
+	Name() string
+	Capabilities() Capability
+	FetchStocks(ctx context.Context, codes []string) ([]models.Stock, error)
+	FetchIndices(ctx context.Context) ([]models.Stock, error)
+	FetchCandles(ctx context.Context, code string, interval models.CandleInterval, from, to time.Time) ([]models.Candle, error)
+}
@@ -0,0 +1,92 @@
+// Package quotetest provides an in-memory quote.Provider for tests of the code built
+// on top of providers.
+package quotetest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ericyhkim/juga/pkg/models"
+	"github.com/ericyhkim/juga/pkg/quote"
+)
+
+// Provider serves quotes built by Stock and the fixed Indices. It supports every
+// capability and is safe for concurrent use.
+type Provider struct {
+	// Stock returns the quote of code. By default only the code is set.
+	Stock func(code string) models.Stock
+	// Indices are returned by FetchIndices.
+	Indices []models.Stock
+
+	mu        sync.Mutex
+	err       error
+	calls     int
+	requested []string
+}
+
+var _ quote.Provider = (*Provider)(nil)
+
+func (p *Provider) Name() string { return "fake" }
+
+func (p *Provider) Capabilities() quote.Capability { return quote.CapAll }
+
+// Fail makes every following fetch return err, or succeed again if err is nil.
+func (p *Provider) Fail(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.err = err
+}
+
+// Calls returns how many quote and index fetches were made.
+func (p *Provider) Calls() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.calls
+}
+
+// Requested returns the codes of the last FetchStocks call.
+func (p *Provider) Requested() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.requested
+}
+
+func (p *Provider) FetchStocks(ctx context.Context, codes []string) ([]models.Stock, error) {
+	p.mu.Lock()
+	p.calls++
+	p.requested = codes
+	err := p.err
+	p.mu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+	stocks := make([]models.Stock, 0, len(codes))
+	for _, code := range codes {
+		if p.Stock != nil {
+			stocks = append(stocks, p.Stock(code))
+		} else {
+			stocks = append(stocks, models.Stock{Code: code})
+		}
+	}
+	return stocks, nil
+}
+
+func (p *Provider) FetchIndices(ctx context.Context) ([]models.Stock, error) {
+	p.mu.Lock()
+	p.calls++
+	err := p.err
+	p.mu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+	return p.Indices, nil
+}
+
+func (p *Provider) FetchCandles(ctx context.Context, code string, interval models.CandleInterval, from, to time.Time) ([]models.Candle, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return nil, p.err
+}
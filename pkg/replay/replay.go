@@ -0,0 +1,250 @@
+// Package replay records HTTP exchanges to a directory and serves them again
+// later without network access, to reproduce a session exactly.
+//
+// Each exchange is saved as a numbered JSON file holding the request method and
+// URL, the time it was made and the response status, headers and body. Bodies that
+// are not UTF-8 (such as Naver's EUC-KR pages) are stored base64-encoded.
+//
+// Requests are matched on their full URL, so URLs derived from the clock (such as
+// the date range of a history request) only match when the replay runs at the
+// recorded time; see Replayer.Now.
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// ErrNotRecorded is returned when replaying a request that was never recorded.
+var ErrNotRecorded = errors.New("request was not recorded")
+
+// Exchange is a recorded request/response pair.
+type Exchange struct {
+	Method     string      `json:"method"`
+	URL        string      `json:"url"`
+	Time       time.Time   `json:"time"`
+	Status     int         `json:"status"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 []byte      `json:"body_base64,omitempty"`
+}
+
+func (e *Exchange) body() []byte {
+	if e.BodyBase64 != nil {
+		return e.BodyBase64
+	}
+	return []byte(e.Body)
+}
+
+func (e *Exchange) setBody(b []byte) {
+	if utf8.Valid(b) {
+		e.Body = string(b)
+	} else {
+		e.BodyBase64 = b
+	}
+}
+
+func key(method, url string) string {
+	return method + " " + url
+}
+
+// Recorder is a transport saving every response it receives to a directory.
+// Requests that fail without a response are not recorded.
+type Recorder struct {
+	dir  string
+	base http.RoundTripper
+	now  func() time.Time
+
+	mu   sync.Mutex
+	next int
+}
+
+// NewRecorder returns a transport sending requests through base (or
+// http.DefaultTransport if nil) and saving the exchanges to dir. Numbering
+// continues after recordings already in dir.
+func NewRecorder(dir string, base http.RoundTripper) (*Recorder, error) {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create recording directory: %w", err)
+	}
+
+	files, err := recordings(dir)
+	if err != nil {
+		return nil, err
+	}
+	next := 1
+	if len(files) > 0 {
+		next = sequence(files[len(files)-1]) + 1
+	}
+
+	return &Recorder{dir: dir, base: base, now: time.Now, next: next}, nil
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	sent := r.now()
+	resp, err := r.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	ex := Exchange{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Time:   sent,
+		Status: resp.StatusCode,
+		Header: resp.Header,
+	}
+	ex.setBody(body)
+
+	if err := r.save(&ex); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *Recorder) save(ex *Exchange) error {
+	data, err := json.MarshalIndent(ex, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	path := filepath.Join(r.dir, fmt.Sprintf("%04d.json", r.next))
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to save recording: %w", err)
+	}
+	r.next++
+	return nil
+}
+
+// Replayer is a transport answering requests from recorded exchanges. Repeated
+// requests for the same URL get the recordings in order; once they run out the
+// last one is served again.
+type Replayer struct {
+	mu        sync.Mutex
+	exchanges map[string][]*Exchange
+	served    map[string]int
+
+	// recorded is when the first exchange was made, and started when the replay
+	// began.
+	recorded time.Time
+	started  time.Time
+}
+
+// NewReplayer loads the exchanges recorded in dir.
+func NewReplayer(dir string) (*Replayer, error) {
+	files, err := recordings(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no recordings found in %s", dir)
+	}
+
+	r := &Replayer{
+		exchanges: make(map[string][]*Exchange),
+		served:    make(map[string]int),
+		started:   time.Now(),
+	}
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var ex Exchange
+		if err := json.Unmarshal(data, &ex); err != nil {
+			return nil, fmt.Errorf("invalid recording %s: %w", path, err)
+		}
+		k := key(ex.Method, ex.URL)
+		r.exchanges[k] = append(r.exchanges[k], &ex)
+		if r.recorded.IsZero() {
+			r.recorded = ex.Time
+		}
+	}
+	return r, nil
+}
+
+// Now is the clock of the recorded session: the time of the first recording plus
+// the time elapsed since the replay began. Code building requests from the current
+// time should use it while replaying. Recordings without times replay at the
+// current time.
+func (r *Replayer) Now() time.Time {
+	if r.recorded.IsZero() {
+		return time.Now()
+	}
+	return r.recorded.Add(time.Since(r.started))
+}
+
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	k := key(req.Method, req.URL.String())
+
+	r.mu.Lock()
+	recorded := r.exchanges[k]
+	i := min(r.served[k], len(recorded)-1)
+	r.served[k]++
+	r.mu.Unlock()
+
+	if len(recorded) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNotRecorded, k)
+	}
+
+	ex := recorded[i]
+	body := ex.body()
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", ex.Status, http.StatusText(ex.Status)),
+		StatusCode:    ex.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        ex.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// recordings returns the recording files in dir in recording order.
+func recordings(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(files, func(a, b string) int {
+		return sequence(a) - sequence(b)
+	})
+	return files, nil
+}
+
+// sequence returns the number of a recording file, or 0 for other files.
+func sequence(path string) int {
+	n, _ := strconv.Atoi(strings.TrimSuffix(filepath.Base(path), ".json"))
+	return n
+}
@@ -0,0 +1,154 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ericyhkim/juga/pkg/diag"
+	"github.com/ericyhkim/juga/pkg/models"
+	"github.com/ericyhkim/juga/pkg/naver"
+	"github.com/ericyhkim/juga/pkg/naver/naverfake"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+	fake := naverfake.NewServer()
+	codes := fake.Codes()[:3]
+
+	recorder, err := NewRecorder(dir, nil)
+	if err != nil {
+		t.Fatalf("NewRecorder() returned error: %v", err)
+	}
+	client := naver.NewClient(diag.NewNopLogger(), naver.WithBaseURL(fake.URL), naver.WithTransport(recorder))
+	recorded, err := client.FetchStocks(context.Background(), codes)
+	if err != nil {
+		t.Fatalf("FetchStocks() returned error: %v", err)
+	}
+
+	scraper := naver.NewScraper(5*time.Second, diag.NewNopLogger(),
+		naver.WithScraperBaseURL(fake.URL), naver.WithScraperTransport(recorder))
+	scraped, err := scraper.ScrapeAll()
+	if err != nil {
+		t.Fatalf("ScrapeAll() returned error: %v", err)
+	}
+	fake.Close()
+
+	replayer, err := NewReplayer(dir)
+	if err != nil {
+		t.Fatalf("NewReplayer() returned error: %v", err)
+	}
+
+	client = naver.NewClient(diag.NewNopLogger(), naver.WithBaseURL(fake.URL), naver.WithTransport(replayer))
+	replayed, err := client.FetchStocks(context.Background(), codes)
+	if err != nil {
+		t.Fatalf("Replayed FetchStocks() returned error: %v", err)
+	}
+	if !reflect.DeepEqual(recorded, replayed) {
+		t.Errorf("Replayed stocks differ:\nrecorded %+v\nreplayed %+v", recorded, replayed)
+	}
+
+	// The EUC-KR market pages are stored base64-encoded and must decode the same.
+	scraper = naver.NewScraper(5*time.Second, diag.NewNopLogger(),
+		naver.WithScraperBaseURL(fake.URL), naver.WithScraperTransport(replayer))
+	rescraped, err := scraper.ScrapeAll()
+	if err != nil {
+		t.Fatalf("Replayed ScrapeAll() returned error: %v", err)
+	}
+	if !reflect.DeepEqual(scraped, rescraped) {
+		t.Errorf("Replayed tickers differ:\nrecorded %+v\nreplayed %+v", scraped, rescraped)
+	}
+
+	_, err = client.FetchStocks(context.Background(), codes[:1])
+	if !errors.Is(err, ErrNotRecorded) {
+		t.Errorf("Expected ErrNotRecorded for a new request, got %v", err)
+	}
+}
+
+func TestReplayer_RepeatsInOrder(t *testing.T) {
+	dir := t.TempDir()
+	fake := naverfake.NewServer()
+	defer fake.Close()
+
+	recorder, err := NewRecorder(dir, nil)
+	if err != nil {
+		t.Fatalf("NewRecorder() returned error: %v", err)
+	}
+	client := naver.NewClient(diag.NewNopLogger(), naver.WithBaseURL(fake.URL), naver.WithTransport(recorder), naver.WithRetries(0, 0))
+
+	fake.FailNext(1, 503)
+	if _, err := client.FetchIndices(context.Background()); err == nil {
+		t.Fatal("Expected the first request to fail")
+	}
+	if _, err := client.FetchIndices(context.Background()); err != nil {
+		t.Fatalf("FetchIndices() returned error: %v", err)
+	}
+
+	// A second recorder continues the numbering.
+	recorder, err = NewRecorder(dir, nil)
+	if err != nil {
+		t.Fatalf("NewRecorder() returned error: %v", err)
+	}
+	if recorder.next != 3 {
+		t.Errorf("Expected the next recording to be 3, got %d", recorder.next)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "0002.json")); err != nil {
+		t.Errorf("Expected 0002.json to exist: %v", err)
+	}
+
+	replayer, err := NewReplayer(dir)
+	if err != nil {
+		t.Fatalf("NewReplayer() returned error: %v", err)
+	}
+	client = naver.NewClient(diag.NewNopLogger(), naver.WithBaseURL(fake.URL), naver.WithTransport(replayer), naver.WithRetries(0, 0))
+
+	for i, wantErr := range []bool{true, false, false} {
+		_, err := client.FetchIndices(context.Background())
+		if (err != nil) != wantErr {
+			t.Errorf("Replay %d: expected error %v, got %v", i+1, wantErr, err)
+		}
+	}
+}
+
+func TestReplayer_HistoryAtRecordedTime(t *testing.T) {
+	dir := t.TempDir()
+	fake := naverfake.NewServer()
+
+	// History requests carry the current date, so replay them days later.
+	recordedAt := time.Date(2026, 10, 16, 15, 40, 0, 0, time.Local)
+	from := recordedAt.AddDate(0, 0, -15)
+
+	recorder, err := NewRecorder(dir, nil)
+	if err != nil {
+		t.Fatalf("NewRecorder() returned error: %v", err)
+	}
+	recorder.now = func() time.Time { return recordedAt }
+	client := naver.NewClient(diag.NewNopLogger(), naver.WithBaseURL(fake.URL), naver.WithTransport(recorder))
+	recorded, err := client.FetchCandles(context.Background(), "005930", models.IntervalDay, from, recorder.now())
+	if err != nil {
+		t.Fatalf("FetchCandles() returned error: %v", err)
+	}
+	fake.Close()
+
+	replayer, err := NewReplayer(dir)
+	if err != nil {
+		t.Fatalf("NewReplayer() returned error: %v", err)
+	}
+	now := replayer.Now()
+	if now.Before(recordedAt) || now.Sub(recordedAt) > time.Minute {
+		t.Fatalf("Expected the replay clock to start at %v, got %v", recordedAt, now)
+	}
+
+	client = naver.NewClient(diag.NewNopLogger(), naver.WithBaseURL(fake.URL), naver.WithTransport(replayer))
+	replayed, err := client.FetchCandles(context.Background(), "005930", models.IntervalDay, now.AddDate(0, 0, -15), now)
+	if err != nil {
+		t.Fatalf("Replayed FetchCandles() returned error: %v", err)
+	}
+	if len(replayed) == 0 || !reflect.DeepEqual(recorded, replayed) {
+		t.Errorf("Replayed candles differ:\nrecorded %+v\nreplayed %+v", recorded, replayed)
+	}
+}
@@ -0,0 +1,128 @@
+package resolver
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ericyhkim/juga/pkg/models"
+	"github.com/ericyhkim/juga/pkg/search"
+)
+
+// Set operators combining the operands of an input such as "@tech+@bio".
+const (
+	opUnion        = '+'
+	opDifference   = '-'
+	opIntersection = '&'
+)
+
+// term is an operand of an input expression with the operator joining it to the
+// operands before it. The first term has no operator.
+type term struct {
+	op      byte
+	operand string
+}
+
+// item is a resolved input together with the search matches behind it.
+type item struct {
+	result  ResolutionResult
+	matches []search.Match
+}
+
+// parseExpression splits input into its operands. An operator only splits when it is
+// followed by a prefixed operand, so names like "S-Oil" or "F&F" are left intact.
+func parseExpression(input string) []term {
+	terms := []term{{}}
+	start := 0
+	for i := 1; i < len(input)-1; i++ {
+		if i == start || !isOperator(input[i]) || !hasInputPrefix(input[i+1:]) {
+			continue
+		}
+		terms[len(terms)-1].operand = input[start:i]
+		terms = append(terms, term{op: input[i]})
+		start = i + 1
+	}
+	terms[len(terms)-1].operand = input[start:]
+	return terms
+}
+
+func isOperator(c byte) bool {
+	return c == opUnion || c == opDifference || c == opIntersection
+}
+
+func hasInputPrefix(s string) bool {
+	for _, p := range []string{models.PrefixPortfolio, models.PrefixAlias, models.PrefixCode, models.PrefixSearch} {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluate resolves an input into items, expanding portfolios and applying set
+// operators from left to right. Unprefixed portfolio names are only expanded at the
+// top level; path holds the portfolios being expanded to detect cycles.
+func (r *Resolver) evaluate(input string, topLevel, write bool, path []string) []item {
+	terms := parseExpression(input)
+	items := r.evaluateOperand(terms[0].operand, topLevel, write, path)
+	for _, t := range terms[1:] {
+		items = combine(items, r.evaluateOperand(t.operand, topLevel, write, path), t.op)
+	}
+	return items
+}
+
+func (r *Resolver) evaluateOperand(operand string, topLevel, write bool, path []string) []item {
+	name, members, ok := r.expand(operand, topLevel)
+	if !ok {
+		res, matches := r.resolve(operand, write)
+		return []item{{result: res, matches: matches}}
+	}
+
+	if slices.Contains(path, name) {
+		cycle := strings.Join(append(slices.Clone(path), name), " → ")
+		r.logger.Warn("Portfolio cycle detected: %s", cycle)
+		return []item{{result: ResolutionResult{
+			Input:  operand,
+			Status: StatusNotFound,
+			Error:  fmt.Errorf("%w: %s", ErrPortfolioCycle, cycle),
+		}}}
+	}
+
+	path = append(path, name)
+	var items []item
+	for _, member := range members {
+		items = append(items, r.evaluate(member, false, write, path)...)
+	}
+	return items
+}
+
+// combine applies a set operator to the stocks of two operands. A union keeps
+// duplicates, which ResolveAll drops later. Unresolved items of either side are
+// kept so that they are still reported.
+func combine(left, right []item, op byte) []item {
+	if op == opUnion {
+		return append(left, right...)
+	}
+
+	codes := make(map[string]bool, len(right))
+	var unresolved []item
+	for _, it := range right {
+		if it.result.Status == StatusSuccess {
+			codes[it.result.Code] = true
+		} else {
+			unresolved = append(unresolved, it)
+		}
+	}
+
+	var items []item
+	for _, it := range left {
+		if it.result.Status != StatusSuccess {
+			items = append(items, it)
+			continue
+		}
+		if codes[it.result.Code] == (op == opIntersection) {
+			items = append(items, it)
+		}
+	}
+	return append(items, unresolved...)
+}
@@ -0,0 +1,125 @@
+package resolver
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ericyhkim/juga/pkg/diag"
+)
+
+func TestParseExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		operands []string
+		ops      string
+	}{
+		{"@tech", []string{"@tech"}, ""},
+		{"@tech+@bio", []string{"@tech", "@bio"}, "+"},
+		{"@tech-:sam", []string{"@tech", ":sam"}, "-"},
+		{"@tech&@dividend+#005930", []string{"@tech", "@dividend", "#005930"}, "&+"},
+		{"S-Oil", []string{"S-Oil"}, ""},
+		{"F&F", []string{"F&F"}, ""},
+		{"@tech-", []string{"@tech-"}, ""},
+	}
+
+	for _, tt := range tests {
+		terms := parseExpression(tt.input)
+		var operands []string
+		var ops string
+		for i, term := range terms {
+			operands = append(operands, term.operand)
+			if i > 0 {
+				ops += string(term.op)
+			}
+		}
+		if !reflect.DeepEqual(operands, tt.operands) || ops != tt.ops {
+			t.Errorf("parseExpression(%q) = %v %q, want %v %q", tt.input, operands, ops, tt.operands, tt.ops)
+		}
+	}
+}
+
+func setupExpressionResolver(portfolios map[string][]string) *Resolver {
+	return NewResolver(
+		&MockPortfolioProvider{Data: portfolios},
+		&MockAliasProvider{Data: map[string]string{"sam": "005930", "hynix": "000660"}},
+		&MockCacheProvider{Data: make(map[string]string)},
+		&MockTickerProvider{},
+		diag.NewNopLogger(),
+	)
+}
+
+func resolvedCodes(results []ResolutionResult) []string {
+	var codes []string
+	for _, res := range results {
+		if res.Status == StatusSuccess {
+			codes = append(codes, res.Code)
+		} else {
+			codes = append(codes, "!"+res.Input)
+		}
+	}
+	return codes
+}
+
+func TestResolveAll_SetAlgebra(t *testing.T) {
+	r := setupExpressionResolver(map[string][]string{
+		"tech":     {"sam", "hynix", "035420"},
+		"bio":      {"207940", "035420"},
+		"dividend": {"005930", "033780"},
+	})
+
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"@tech+@bio", []string{"005930", "000660", "035420", "207940"}},
+		{"@tech-:sam", []string{"000660", "035420"}},
+		{"@tech&@dividend", []string{"005930"}},
+		{"@tech&@bio-#035420", nil},
+		{"@tech-:nope", []string{"005930", "000660", "035420", "!:nope"}},
+		{"tech-#000660", []string{"005930", "035420"}},
+	}
+
+	for _, tt := range tests {
+		got := resolvedCodes(r.ResolveAll([]string{tt.input}))
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ResolveAll(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestResolveAll_NestedPortfolios(t *testing.T) {
+	r := setupExpressionResolver(map[string][]string{
+		"all":  {"@tech", "@bio", "005930"},
+		"tech": {"sam", "hynix"},
+		"bio":  {"207940"},
+		"loop": {"@self"},
+		"self": {"sam", "@loop"},
+	})
+
+	got := resolvedCodes(r.ResolveAll([]string{"@all"}))
+	want := []string{"005930", "000660", "207940"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected nested portfolios to expand to %v, got %v", want, got)
+	}
+
+	results := r.ResolveAll([]string{"@loop"})
+	if len(results) != 2 || results[0].Code != "005930" {
+		t.Fatalf("Expected the cycle to stop after one pass, got %v", results)
+	}
+	if !errors.Is(results[1].Error, ErrPortfolioCycle) {
+		t.Errorf("Expected a cycle error, got %v", results[1].Error)
+	}
+}
+
+func TestResolveAll_NestedItemsNeedPrefix(t *testing.T) {
+	r := setupExpressionResolver(map[string][]string{
+		"tech": {"bio"},
+		"bio":  {"207940"},
+	})
+
+	results := r.ResolveAll([]string{"@tech"})
+	if len(results) != 1 || results[0].Input != "bio" || results[0].Status != StatusNotFound {
+		t.Errorf("Expected the unprefixed item 'bio' to be resolved as a stock, got %v", results)
+	}
+}
@@ -13,10 +13,15 @@ type AliasProvider interface {
 type CacheProvider interface {
 	Get(term string) (string, bool)
 	Set(term, code string)
+	// TickerVersion is the ticker database version the entries were last checked against.
+	TickerVersion() string
+	// Revalidate drops the entries rejected by keep and records the ticker version.
+	Revalidate(version string, keep func(term, code string) bool) int
 }
 
 type TickerProvider interface {
 	GetAll() []models.Ticker
 	Count() int
 	Load() error
+	Version() string
 }
@@ -20,7 +20,8 @@ func (m *MockAliasProvider) Resolve(name string) string {
 }
 
 type MockCacheProvider struct {
-	Data map[string]string
+	Data    map[string]string
+	Version string
 }
 
 func (m *MockCacheProvider) Get(term string) (string, bool) {
@@ -35,8 +36,25 @@ func (m *MockCacheProvider) Set(term, code string) {
 	m.Data[term] = code
 }
 
+func (m *MockCacheProvider) TickerVersion() string {
+	return m.Version
+}
+
+func (m *MockCacheProvider) Revalidate(version string, keep func(term, code string) bool) int {
+	removed := 0
+	for term, code := range m.Data {
+		if !keep(term, code) {
+			delete(m.Data, term)
+			removed++
+		}
+	}
+	m.Version = version
+	return removed
+}
+
 type MockTickerProvider struct {
 	Tickers []models.Ticker
+	Ver     string
 }
 
 func (m *MockTickerProvider) GetAll() []models.Ticker {
@@ -50,3 +68,7 @@ func (m *MockTickerProvider) Count() int {
 func (m *MockTickerProvider) Load() error {
 	return nil
 }
+
+func (m *MockTickerProvider) Version() string {
+	return m.Ver
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/ericyhkim/juga/pkg/models"
+	"github.com/ericyhkim/juga/pkg/search"
 )
 
 type ResolutionSource string
@@ -35,6 +36,39 @@ type ResolutionResult struct {
 	Error       error
 }
 
+// Explanation records how one input given to ResolveAll was resolved.
+type Explanation struct {
+	Input string
+	// Portfolio is the name of the portfolio the input expanded to, if any.
+	Portfolio string
+	// Expression marks inputs combining operands with set operators.
+	Expression bool
+	// Shadowed is what an unprefixed portfolio name would resolve to otherwise.
+	Shadowed []Shadow
+	Steps    []Step
+}
+
+// Step is the resolution of a single item: the input itself, or one portfolio item.
+type Step struct {
+	Result ResolutionResult
+	// Matches are the scored search candidates for search and cache resolutions.
+	Matches []search.Match
+	// CacheKey is the cache entry a search resolution writes, if any.
+	CacheKey string
+	// Shadowed lists lower-priority sources that would resolve the item differently.
+	Shadowed []Shadow
+	// DuplicateOf is the earlier item that resolved to the same code. ResolveAll
+	// drops duplicates.
+	DuplicateOf string
+}
+
+// Shadow is a resolution hidden by a higher-priority source.
+type Shadow struct {
+	Source ResolutionSource
+	Code   string
+}
+
 var (
-	ErrNotFound = errors.New("stock not found")
+	ErrNotFound       = errors.New("stock not found")
+	ErrPortfolioCycle = errors.New("portfolio cycle")
 )
@@ -15,6 +15,9 @@ type Resolver struct {
 	cache      CacheProvider
 	tickers    TickerProvider
 	logger     diag.Logger
+
+	// cacheChecked is set once the cache has been checked against the ticker database.
+	cacheChecked bool
 }
 
 func NewResolver(
@@ -33,45 +36,137 @@ func NewResolver(
 	}
 }
 
+// ResolveAll resolves command-line inputs in order. Inputs may combine portfolios,
+// aliases, codes and searches with set operators, e.g. "@tech+@bio" or "@tech-:sam".
+// Later results for an already resolved code are dropped.
 func (r *Resolver) ResolveAll(inputs []string) []ResolutionResult {
-	var expandedInputs []string
+	results := make([]ResolutionResult, 0, len(inputs))
+	seen := make(map[string]bool)
+
 	for _, input := range inputs {
-		if strings.HasPrefix(input, models.PrefixPortfolio) {
-			name := strings.TrimPrefix(input, models.PrefixPortfolio)
-			if items, ok := r.portfolios.Get(name); ok {
-				expandedInputs = append(expandedInputs, items...)
-				continue
+		for _, it := range r.evaluate(input, true, true, nil) {
+			res := it.result
+
+			if res.Status == StatusSuccess {
+				if seen[res.Code] {
+					continue
+				}
+				seen[res.Code] = true
 			}
+
+			results = append(results, res)
 		}
+	}
 
-		// Fallback to legacy behavior: check if it's a portfolio without prefix
-		if items, ok := r.portfolios.Get(input); ok {
-			expandedInputs = append(expandedInputs, items...)
-		} else {
-			expandedInputs = append(expandedInputs, input)
+	return results
+}
+
+// expand returns the items of the portfolio named by input, if there is one.
+// Unprefixed names only count when legacy is set.
+func (r *Resolver) expand(input string, legacy bool) (string, []string, bool) {
+	if strings.HasPrefix(input, models.PrefixPortfolio) {
+		name := strings.TrimPrefix(input, models.PrefixPortfolio)
+		if items, ok := r.portfolios.Get(name); ok {
+			return name, items, true
 		}
 	}
 
-	results := make([]ResolutionResult, 0, len(expandedInputs))
-	seen := make(map[string]bool)
+	// Fallback to legacy behavior: check if it's a portfolio without prefix
+	if !legacy {
+		return "", nil, false
+	}
+	if items, ok := r.portfolios.Get(input); ok {
+		return input, items, true
+	}
+	return "", nil, false
+}
+
+// Explain resolves inputs the way ResolveAll does and records every step, without
+// writing to the cache.
+func (r *Resolver) Explain(inputs []string) []Explanation {
+	explanations := make([]Explanation, 0, len(inputs))
+	seen := make(map[string]string)
+
+	for _, input := range inputs {
+		e := Explanation{Input: input}
 
-	for _, input := range expandedInputs {
-		res := r.Resolve(input)
+		if len(parseExpression(input)) > 1 {
+			e.Expression = true
+		} else if name, _, ok := r.expand(input, true); ok {
+			e.Portfolio = name
 
-		if res.Status == StatusSuccess {
-			if seen[res.Code] {
-				continue
+			// An unprefixed portfolio name hides whatever the word would resolve to.
+			if !strings.HasPrefix(input, models.PrefixPortfolio) {
+				if res, _ := r.resolve(input, false); res.Status == StatusSuccess {
+					e.Shadowed = append(e.Shadowed, Shadow{Source: res.Source, Code: res.Code})
+				}
 			}
-			seen[res.Code] = true
 		}
 
-		results = append(results, res)
+		for _, it := range r.evaluate(input, true, false, nil) {
+			res := it.result
+			step := Step{Result: res, Matches: it.matches}
+
+			if res.Source == SourceSearch && !strings.HasPrefix(res.Input, models.PrefixSearch) {
+				step.CacheKey = res.Input
+			}
+			if res.Source == SourceCache {
+				step.Matches = search.RankTickers(r.loadTickers(), res.Input)
+			}
+			step.Shadowed = r.shadowed(res.Input, res, step.Matches)
+
+			if res.Status == StatusSuccess {
+				if first, ok := seen[res.Code]; ok {
+					step.DuplicateOf = first
+				} else {
+					seen[res.Code] = res.Input
+				}
+			}
+
+			e.Steps = append(e.Steps, step)
+		}
+
+		explanations = append(explanations, e)
 	}
 
-	return results
+	return explanations
+}
+
+// shadowed lists the lower-priority sources that disagree with how an unprefixed
+// input was resolved: a cache entry hidden by an alias, or a fresh search result
+// hidden by a (possibly stale) cache entry.
+func (r *Resolver) shadowed(input string, res ResolutionResult, matches []search.Match) []Shadow {
+	var shadows []Shadow
+	switch res.Source {
+	case SourceAlias:
+		if strings.HasPrefix(input, models.PrefixAlias) {
+			break
+		}
+		if cached, ok := r.cache.Get(input); ok && cached != res.Code {
+			shadows = append(shadows, Shadow{Source: SourceCache, Code: cached})
+		}
+	case SourceCache:
+		if len(matches) > 0 && matches[0].Ticker.Code != res.Code {
+			shadows = append(shadows, Shadow{Source: SourceSearch, Code: matches[0].Ticker.Code})
+		}
+	}
+	return shadows
 }
 
 func (r *Resolver) Resolve(input string) ResolutionResult {
+	res, _ := r.resolve(input, true)
+	return res
+}
+
+// Lookup resolves a single input like Resolve, without caching search results.
+func (r *Resolver) Lookup(input string) ResolutionResult {
+	res, _ := r.resolve(input, false)
+	return res
+}
+
+// resolve runs the resolution chain for a single input. Search results are cached
+// only when write is set; the scored matches are returned for searches.
+func (r *Resolver) resolve(input string, write bool) (ResolutionResult, []search.Match) {
 	if strings.HasPrefix(input, models.PrefixAlias) {
 		nick := strings.TrimPrefix(input, models.PrefixAlias)
 		if resolved := r.aliases.Resolve(nick); resolved != "" {
@@ -80,13 +175,13 @@ func (r *Resolver) Resolve(input string) ResolutionResult {
 				Code:   resolved,
 				Source: SourceAlias,
 				Status: StatusSuccess,
-			}
+			}, nil
 		}
 		return ResolutionResult{
 			Input:  input,
 			Status: StatusNotFound,
 			Error:  fmt.Errorf("%w: alias '%s'", ErrNotFound, nick),
-		}
+		}, nil
 	}
 
 	if strings.HasPrefix(input, models.PrefixCode) {
@@ -97,18 +192,18 @@ func (r *Resolver) Resolve(input string) ResolutionResult {
 				Code:   code,
 				Source: SourceCode,
 				Status: StatusSuccess,
-			}
+			}, nil
 		}
 		return ResolutionResult{
 			Input:  input,
 			Status: StatusNotFound,
 			Error:  fmt.Errorf("invalid stock code: %s", code),
-		}
+		}, nil
 	}
 
 	if strings.HasPrefix(input, models.PrefixSearch) {
 		query := strings.TrimPrefix(input, models.PrefixSearch)
-		return r.resolveSearch(input, query, true)
+		return r.resolveSearch(input, query, true, write)
 	}
 
 	if resolved := r.aliases.Resolve(input); resolved != "" {
@@ -117,7 +212,7 @@ func (r *Resolver) Resolve(input string) ResolutionResult {
 			Code:   resolved,
 			Source: SourceAlias,
 			Status: StatusSuccess,
-		}
+		}, nil
 	}
 
 	if models.IsValidCode(input) {
@@ -126,37 +221,37 @@ func (r *Resolver) Resolve(input string) ResolutionResult {
 			Code:   input,
 			Source: SourceCode,
 			Status: StatusSuccess,
-		}
+		}, nil
 	}
 
+	r.checkCache()
 	if cached, ok := r.cache.Get(input); ok {
 		return ResolutionResult{
 			Input:  input,
 			Code:   cached,
 			Source: SourceCache,
 			Status: StatusSuccess,
-		}
+		}, nil
 	}
 
-	return r.resolveSearch(input, input, false)
+	return r.resolveSearch(input, input, false, write)
 }
 
-func (r *Resolver) resolveSearch(input, query string, isExplicit bool) ResolutionResult {
-	if r.tickers.Count() == 0 {
-		if err := r.tickers.Load(); err != nil {
-			r.logger.Error("Failed to load ticker list: %v", err)
+func (r *Resolver) resolveSearch(input, query string, isExplicit, write bool) (ResolutionResult, []search.Match) {
+	matches := search.RankTickers(r.loadTickers(), query)
+	if len(matches) > 0 {
+		results := make([]models.Ticker, 0, len(matches))
+		for _, m := range matches {
+			results = append(results, m.Ticker)
 		}
-	}
-
-	results := search.FindTickers(r.tickers.GetAll(), query)
-	if len(results) > 0 {
 		bestMatch := results[0]
 
-		if !isExplicit {
+		if !isExplicit && write {
 			r.cache.Set(input, bestMatch.Code)
 		}
 
-		isAmbiguous := len(results) > 1
+		// An exact name match is what the user meant even if longer names also match.
+		isAmbiguous := len(results) > 1 && !search.IsExactMatch(bestMatch, query)
 
 		return ResolutionResult{
 			Input:       input,
@@ -166,12 +261,89 @@ func (r *Resolver) resolveSearch(input, query string, isExplicit bool) Resolutio
 			Status:      StatusSuccess,
 			IsAmbiguous: isAmbiguous,
 			Candidates:  results,
-		}
+		}, matches
 	}
 
 	return ResolutionResult{
 		Input:  input,
 		Status: StatusNotFound,
 		Error:  fmt.Errorf("%w: %s", ErrNotFound, query),
+	}, nil
+}
+
+// RecordChoice caches the code the user picked for an ambiguous input, replacing the
+// best match cached by the search, so the input resolves to it directly next time.
+// Explicit searches bypass the cache and are not recorded.
+func (r *Resolver) RecordChoice(input, code string) {
+	if strings.HasPrefix(input, models.PrefixSearch) {
+		return
+	}
+	r.cache.Set(input, code)
+}
+
+// checkCache revalidates the cache the first time it is used if the ticker database
+// changed since the entries were last checked.
+func (r *Resolver) checkCache() {
+	if r.cacheChecked {
+		return
+	}
+	r.cacheChecked = true
+
+	// Without a ticker database every entry would look stale.
+	if len(r.loadTickers()) == 0 {
+		return
+	}
+	if version := r.tickers.Version(); version != r.cache.TickerVersion() {
+		if removed := r.RevalidateCache(); removed > 0 {
+			r.logger.Debug("Dropped %d stale cache entries", removed)
+		}
+	}
+}
+
+// RevalidateCache drops the cache entries that no longer fit the ticker database,
+// because their code was delisted or their term no longer matches the ticker (after
+// a rename, for instance), and returns how many were dropped.
+func (r *Resolver) RevalidateCache() int {
+	r.cacheChecked = true
+
+	tickers := r.loadTickers()
+	if len(tickers) == 0 {
+		return 0
+	}
+	byCode := make(map[string]models.Ticker, len(tickers))
+	for _, t := range tickers {
+		byCode[t.Code] = t
+	}
+
+	return r.cache.Revalidate(r.tickers.Version(), func(term, code string) bool {
+		t, ok := byCode[code]
+		return ok && len(search.FindTickers([]models.Ticker{t}, term)) > 0
+	})
+}
+
+// loadTickers returns the ticker database, loading it on first use.
+func (r *Resolver) loadTickers() []models.Ticker {
+	if r.tickers.Count() == 0 {
+		if err := r.tickers.Load(); err != nil {
+			r.logger.Error("Failed to load ticker list: %v", err)
+		}
+	}
+	return r.tickers.GetAll()
+}
+
+// NameOf returns the name of the ticker with the given code, or an empty string when
+// the code is not in the ticker database.
+func (r *Resolver) NameOf(code string) string {
+	t, _ := r.TickerOf(code)
+	return t.Name
+}
+
+// TickerOf looks up a code in the ticker database.
+func (r *Resolver) TickerOf(code string) (models.Ticker, bool) {
+	for _, t := range r.loadTickers() {
+		if t.Code == code {
+			return t, true
+		}
 	}
+	return models.Ticker{}, false
 }
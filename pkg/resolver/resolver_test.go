@@ -72,4 +72,169 @@ func TestResolveAll_Portfolio(t *testing.T) {
 	if results[0].Input != "sam" || results[0].Code != "005930" {
 		t.Errorf("Expected first item to be resolved alias 'sam', got %v", results[0])
 	}
-}
\ No newline at end of file
+}
+
+func TestNameOf(t *testing.T) {
+	r := setupTestResolver(t)
+	r.tickers = &MockTickerProvider{
+		Tickers: []models.Ticker{{Code: "005930", Name: "삼성전자", Market: "KOSPI"}},
+	}
+
+	if name := r.NameOf("005930"); name != "삼성전자" {
+		t.Errorf("Expected 삼성전자, got %q", name)
+	}
+	if name := r.NameOf("999999"); name != "" {
+		t.Errorf("Expected empty name for unknown code, got %q", name)
+	}
+	if ticker, ok := r.TickerOf("005930"); !ok || ticker.Market != "KOSPI" {
+		t.Errorf("Expected KOSPI ticker, got %v (found=%v)", ticker, ok)
+	}
+}
+
+func TestResolve_ExactNameIsNotAmbiguous(t *testing.T) {
+	r := setupTestResolver(t)
+	r.tickers = &MockTickerProvider{
+		Tickers: []models.Ticker{
+			{Code: "323410", Name: "카카오뱅크", Market: "KOSPI"},
+			{Code: "035720", Name: "카카오", Market: "KOSPI"},
+		},
+	}
+
+	res := r.Resolve("카카오")
+	if res.Code != "035720" || res.IsAmbiguous {
+		t.Errorf("Expected an unambiguous exact match, got %+v", res)
+	}
+
+	res = r.Resolve("카카")
+	if !res.IsAmbiguous {
+		t.Errorf("Expected a partial query to stay ambiguous, got %+v", res)
+	}
+}
+
+func TestResolve_TypoDoesNotAddAmbiguity(t *testing.T) {
+	r := setupTestResolver(t)
+	r.tickers = &MockTickerProvider{
+		Tickers: []models.Ticker{
+			{Code: "009830", Name: "한화솔루션", Market: "KOSPI"},
+			{Code: "000370", Name: "한화손해보험", Market: "KOSPI"},
+		},
+	}
+
+	// 한화손해보험 is one edit away from 한화솔, but 한화솔루션 matches as typed.
+	res := r.Resolve("한화솔")
+	if res.Code != "009830" || res.IsAmbiguous || len(res.Candidates) != 1 {
+		t.Errorf("Expected 한화솔 to resolve directly, got %+v", res)
+	}
+
+	res = r.Resolve("한화솔루숀")
+	if res.Code != "009830" {
+		t.Errorf("Expected a typo to still find 한화솔루션, got %+v", res)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	r := setupTestResolver(t)
+	r.tickers = &MockTickerProvider{
+		Tickers: []models.Ticker{
+			{Code: "005930", Name: "삼성전자", Market: "KOSPI"},
+			{Code: "035720", Name: "카카오", Market: "KOSPI"},
+		},
+	}
+	r.cache = &MockCacheProvider{Data: map[string]string{"삼전": "000660"}}
+	r.aliases.(*MockAliasProvider).Data["tech"] = "035720"
+
+	explanations := r.Explain([]string{"tech", "삼전", "카카오"})
+	if len(explanations) != 3 {
+		t.Fatalf("Expected 3 explanations, got %d", len(explanations))
+	}
+
+	tech := explanations[0]
+	if tech.Portfolio != "tech" || len(tech.Steps) != 2 {
+		t.Fatalf("Expected 'tech' to expand to its 2 items, got %+v", tech)
+	}
+	if len(tech.Shadowed) != 1 || tech.Shadowed[0].Source != SourceAlias {
+		t.Errorf("Expected the portfolio to shadow the 'tech' alias, got %+v", tech.Shadowed)
+	}
+	if tech.Steps[1].CacheKey != "kakao" || tech.Steps[1].Result.Code != "035720" {
+		t.Errorf("Expected 'kakao' to be searched, got %+v", tech.Steps[1])
+	}
+
+	stale := explanations[1].Steps[0]
+	if stale.Result.Source != SourceCache || stale.Result.Code != "000660" {
+		t.Errorf("Expected a cache hit, got %+v", stale.Result)
+	}
+	if len(stale.Shadowed) != 1 || stale.Shadowed[0].Code != "005930" {
+		t.Errorf("Expected the cache to shadow a fresh search for 005930, got %+v", stale.Shadowed)
+	}
+
+	kakao := explanations[2].Steps[0]
+	if kakao.Result.Source != SourceSearch || kakao.CacheKey != "카카오" || len(kakao.Matches) == 0 {
+		t.Errorf("Expected a search writing cache key 카카오, got %+v", kakao)
+	}
+	if kakao.DuplicateOf != "kakao" {
+		t.Errorf("Expected 카카오 to duplicate the portfolio item 'kakao', got %q", kakao.DuplicateOf)
+	}
+
+	if _, ok := r.cache.Get("카카오"); ok {
+		t.Error("Explain must not write to the cache")
+	}
+}
+
+func TestRevalidateCache(t *testing.T) {
+	r := setupTestResolver(t)
+	r.tickers = &MockTickerProvider{
+		Ver: "v2",
+		Tickers: []models.Ticker{
+			{Code: "005930", Name: "삼성전자", Market: "KOSPI"},
+			{Code: "035720", Name: "카카오", Market: "KOSPI"},
+		},
+	}
+	cache := &MockCacheProvider{
+		Version: "v1",
+		Data: map[string]string{
+			"삼전":   "005930", // still matches
+			"다음":   "035720", // the ticker was renamed away from the term
+			"셀트리온": "068270", // delisted
+		},
+	}
+	r.cache = cache
+
+	if res := r.Resolve("다음"); res.Source == SourceCache {
+		t.Errorf("Expected the stale entry to be dropped before lookup, got %+v", res)
+	}
+	if _, ok := cache.Data["셀트리온"]; ok {
+		t.Error("Expected the delisted entry to be dropped")
+	}
+	if code, ok := cache.Data["삼전"]; !ok || code != "005930" {
+		t.Error("Expected the valid entry to be kept")
+	}
+	if cache.Version != "v2" {
+		t.Errorf("Expected the cache to record ticker version v2, got %q", cache.Version)
+	}
+}
+
+func TestRecordChoice(t *testing.T) {
+	r := setupTestResolver(t)
+	r.tickers = &MockTickerProvider{
+		Tickers: []models.Ticker{
+			{Code: "035720", Name: "카카오", Market: "KOSPI"},
+			{Code: "323410", Name: "카카오뱅크", Market: "KOSPI"},
+		},
+	}
+
+	res := r.Resolve("카카")
+	if !res.IsAmbiguous {
+		t.Fatalf("Expected an ambiguous result, got %+v", res)
+	}
+
+	r.RecordChoice(res.Input, "323410")
+	res = r.Resolve("카카")
+	if res.Source != SourceCache || res.Code != "323410" || res.IsAmbiguous {
+		t.Errorf("Expected the picked code to be cached, got %+v", res)
+	}
+
+	r.RecordChoice("/카카", "323410")
+	if _, ok := r.cache.Get("/카카"); ok {
+		t.Error("Expected explicit searches not to be recorded")
+	}
+}
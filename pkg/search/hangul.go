@@ -0,0 +1,72 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	hangulFirst = 0xAC00 // 가
+	hangulLast  = 0xD7A3 // 힣
+	// Each initial consonant spans 21 vowels × 28 optional finals.
+	syllablesPerInitial = 21 * 28
+)
+
+// initials are the compatibility jamo for the 19 leading consonants, in Unicode order.
+var initials = []rune("ㄱㄲㄴㄷㄸㄹㅁㅂㅃㅅㅆㅇㅈㅉㅊㅋㅌㅍㅎ")
+
+func isSyllable(r rune) bool {
+	return r >= hangulFirst && r <= hangulLast
+}
+
+// isConsonant reports whether r is a standalone consonant as typed on a Korean
+// keyboard (ㄱ…ㅎ).
+func isConsonant(r rune) bool {
+	return r >= 'ㄱ' && r <= 'ㅎ'
+}
+
+// initialOf returns the leading consonant of a Hangul syllable, or r itself.
+func initialOf(r rune) rune {
+	if !isSyllable(r) {
+		return r
+	}
+	return initials[(r-hangulFirst)/syllablesPerInitial]
+}
+
+// Chosung replaces every Hangul syllable with its initial consonant, so
+// Chosung("삼성전자") is "ㅅㅅㅈㅈ". Other characters are kept as they are.
+func Chosung(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		sb.WriteRune(initialOf(r))
+	}
+	return sb.String()
+}
+
+// hasConsonant reports whether s contains a standalone consonant, i.e. whether it
+// should be treated as a (possibly mixed) chosung query.
+func hasConsonant(s string) bool {
+	for _, r := range s {
+		if isConsonant(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesChosung reports whether query is a subsequence of name where a standalone
+// consonant in the query stands for any syllable starting with it, e.g. "삼ㅈ"
+// matches "삼성전자" but "상ㅈ" does not.
+func matchesChosung(query, name string) bool {
+	q := []rune(query)
+	i := 0
+	for _, r := range name {
+		if i == len(q) {
+			break
+		}
+		if q[i] == r || unicode.ToLower(q[i]) == unicode.ToLower(r) || (isConsonant(q[i]) && initialOf(r) == q[i]) {
+			i++
+		}
+	}
+	return i == len(q)
+}
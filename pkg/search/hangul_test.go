@@ -0,0 +1,36 @@
+package search
+
+import "testing"
+
+func TestChosung(t *testing.T) {
+	tests := map[string]string{
+		"삼성전자":   "ㅅㅅㅈㅈ",
+		"SK하이닉스": "SKㅎㅇㄴㅅ",
+		"쌍용C&E":  "ㅆㅇC&E",
+		"ㄱ가":     "ㄱㄱ",
+		"":       "",
+	}
+	for in, want := range tests {
+		if got := Chosung(in); got != want {
+			t.Errorf("Chosung(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestMatchesChosung(t *testing.T) {
+	tests := []struct {
+		query, name string
+		want        bool
+	}{
+		{"ㅅㅅㅈㅈ", "삼성전자", true},
+		{"삼ㅈ", "삼성전자", true},
+		{"상ㅈ", "삼성전자", false},
+		{"skㅎ", "SK하이닉스", true},
+		{"ㅅㅈ", "카카오", false},
+	}
+	for _, tt := range tests {
+		if got := matchesChosung(tt.query, tt.name); got != tt.want {
+			t.Errorf("matchesChosung(%q, %q) = %v, want %v", tt.query, tt.name, got, tt.want)
+		}
+	}
+}
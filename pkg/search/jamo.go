@@ -0,0 +1,78 @@
+package search
+
+const (
+	// minTypoQueryJamo avoids typo matching very short queries, where a single edit
+	// would match a large part of the ticker list.
+	minTypoQueryJamo = 4
+	// jamoPerTypo allows one edit for every this many jamo in the query.
+	jamoPerTypo = 5
+	// typoPenalty ranks each edit below any amount of unmatched trailing jamo.
+	typoPenalty = 100
+)
+
+// decompose splits Hangul syllables into their jamo so edit distance counts a wrong
+// vowel or consonant as one edit instead of a whole wrong syllable. Standalone
+// consonants are mapped to the leading-consonant jamo so that chosung queries line
+// up with decomposed syllables. Other characters are kept as they are.
+func decompose(s string) []rune {
+	var out []rune
+	for _, r := range s {
+		switch {
+		case isSyllable(r):
+			offset := r - hangulFirst
+			out = append(out,
+				0x1100+offset/syllablesPerInitial,      // leading consonant
+				0x1161+(offset%syllablesPerInitial)/28, // vowel
+			)
+			if final := offset % 28; final != 0 {
+				out = append(out, 0x11A7+final)
+			}
+		case isConsonant(r):
+			out = append(out, leadingJamo(r))
+		default:
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// leadingJamo maps a standalone consonant to its leading-consonant jamo when it can
+// start a syllable (ㄳ and the like cannot, and are returned unchanged).
+func leadingJamo(r rune) rune {
+	for i, c := range initials {
+		if c == r {
+			return 0x1100 + rune(i)
+		}
+	}
+	return r
+}
+
+// prefixDistance returns the smallest Levenshtein distance between q and any prefix
+// of name, along with the length of that prefix.
+func prefixDistance(q, name []rune) (int, int) {
+	prev := make([]int, len(name)+1)
+	curr := make([]int, len(name)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(q); i++ {
+		curr[0] = i
+		for j := 1; j <= len(name); j++ {
+			cost := 1
+			if q[i-1] == name[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	best, end := prev[0], 0
+	for j := 1; j <= len(name); j++ {
+		if prev[j] < best {
+			best, end = prev[j], j
+		}
+	}
+	return best, end
+}
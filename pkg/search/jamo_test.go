@@ -0,0 +1,46 @@
+package search
+
+import "testing"
+
+func TestPrefixDistance(t *testing.T) {
+	tests := []struct {
+		query, name string
+		dist        int
+	}{
+		{"삼성전자", "삼성전자", 0},
+		{"삼승전자", "삼성전자", 1},   // ㅓ → ㅡ
+		{"카카오벵크", "카카오뱅크", 1}, // ㅐ → ㅔ
+		{"삼성", "삼성전자", 0},     // trailing jamo of the name are free
+		{"ㅅㅓㅁ", "섬", 2},       // standalone vowels and finals do not line up
+		{"현데차", "현대차", 1},
+	}
+	for _, tt := range tests {
+		if dist, _ := prefixDistance(decompose(tt.query), decompose(tt.name)); dist != tt.dist {
+			t.Errorf("prefixDistance(%q, %q) = %d, want %d", tt.query, tt.name, dist, tt.dist)
+		}
+	}
+}
+
+func TestFindTickers_Typo(t *testing.T) {
+	tickers := TickerSource{
+		{Code: "323410", Name: "카카오뱅크", Market: "KOSPI"},
+		{Code: "005930", Name: "삼성전자", Market: "KOSPI"},
+		{Code: "005935", Name: "삼성전자우", Market: "KOSPI"},
+		{Code: "000001", Name: "삼승", Market: "KOSDAQ"},
+	}
+
+	results := FindTickers(tickers, "삼승전자")
+	if len(results) < 2 || results[0].Name != "삼성전자" || results[1].Name != "삼성전자우" {
+		t.Errorf("Expected typo matches ranked by leftover length, got %v", results)
+	}
+
+	results = FindTickers(tickers, "삼승")
+	if len(results) == 0 || results[0].Name != "삼승" {
+		t.Errorf("Expected the subsequence match before typo matches, got %v", results)
+	}
+
+	results = FindTickers(tickers, "카카오벵크")
+	if len(results) != 1 || results[0].Name != "카카오뱅크" {
+		t.Errorf("Expected 카카오뱅크, got %v", results)
+	}
+}
@@ -0,0 +1,70 @@
+package search
+
+import "strings"
+
+// Revised Romanization of the syllable components, indexed in Unicode order.
+var (
+	romanInitials = []string{"g", "kk", "n", "d", "tt", "r", "m", "b", "pp", "s", "ss", "", "j", "jj", "ch", "k", "t", "p", "h"}
+	romanMedials  = []string{"a", "ae", "ya", "yae", "eo", "e", "yeo", "ye", "o", "wa", "wae", "oe", "yo", "u", "wo", "we", "wi", "yu", "eu", "ui", "i"}
+	romanFinals   = []string{"", "k", "k", "k", "n", "n", "n", "t", "l", "k", "m", "l", "l", "l", "p", "l", "m", "p", "p", "t", "t", "ng", "t", "t", "k", "t", "p", "t"}
+
+	// romanLinkedFinals is how a final consonant is pronounced when the next syllable
+	// starts with a silent ㅇ (연음), e.g. 국어 → gugeo. Clusters keep their coda form.
+	romanLinkedFinals = map[int]string{
+		1: "g", 2: "kk", 4: "n", 7: "d", 8: "r", 16: "m", 17: "b",
+		19: "s", 20: "ss", 22: "j", 23: "ch", 24: "k", 25: "t", 26: "p", 27: "",
+	}
+)
+
+const silentInitial = 11 // ㅇ
+
+// Romanize transliterates Hangul syllables with a simplified Revised Romanization:
+// letters follow the standard tables and a final consonant moves to a following
+// silent ㅇ, but other sound changes are ignored. 카카오 becomes "kakao" and 삼성전자
+// becomes "samseongjeonja". Non-Hangul characters are kept unchanged.
+func Romanize(s string) string {
+	runes := []rune(s)
+
+	var sb strings.Builder
+	for i, r := range runes {
+		if !isSyllable(r) {
+			sb.WriteRune(r)
+			continue
+		}
+
+		offset := int(r - hangulFirst)
+		initial := offset / syllablesPerInitial
+		medial := (offset % syllablesPerInitial) / 28
+		final := offset % 28
+
+		sb.WriteString(romanInitials[initial])
+		sb.WriteString(romanMedials[medial])
+
+		if final == 0 {
+			continue
+		}
+		if i+1 < len(runes) && isSyllable(runes[i+1]) && int(runes[i+1]-hangulFirst)/syllablesPerInitial == silentInitial {
+			if linked, ok := romanLinkedFinals[final]; ok {
+				sb.WriteString(linked)
+				continue
+			}
+		}
+		sb.WriteString(romanFinals[final])
+	}
+	return sb.String()
+}
+
+// isLatinQuery reports whether the query contains Latin letters and no Hangul, i.e.
+// was typed without a Korean keyboard.
+func isLatinQuery(s string) bool {
+	hasLatin := false
+	for _, r := range s {
+		switch {
+		case isSyllable(r) || isConsonant(r):
+			return false
+		case (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z'):
+			hasLatin = true
+		}
+	}
+	return hasLatin
+}
@@ -0,0 +1,19 @@
+package search
+
+import "testing"
+
+func TestRomanize(t *testing.T) {
+	tests := map[string]string{
+		"카카오":    "kakao",
+		"삼성전자":   "samseongjeonja",
+		"현대차":    "hyeondaecha",
+		"SK하이닉스": "SKhainikseu",
+		"한국어":    "hangugeo",
+		"셀트리온":   "selteurion",
+	}
+	for in, want := range tests {
+		if got := Romanize(in); got != want {
+			t.Errorf("Romanize(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
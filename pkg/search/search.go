@@ -1,7 +1,9 @@
 package search
 
 import (
+	"math"
 	"sort"
+	"strings"
 
 	"github.com/ericyhkim/juga/pkg/models"
 	"github.com/sahilm/fuzzy"
@@ -17,20 +19,269 @@ func (t TickerSource) Len() int {
 	return len(t)
 }
 
+// nameSource exposes an alternative spelling of every ticker name, such as its
+// initial consonants or its romanization.
+type nameSource []string
+
+func (n nameSource) String(i int) string {
+	return n[i]
+}
+
+func (n nameSource) Len() int {
+	return len(n)
+}
+
+const (
+	rankBonusMax       = 30
+	rankBonusPerDecade = 10
+	// codePrefixScore ranks code prefix matches above fuzzy name matches of digits.
+	codePrefixScore = 100
+)
+
+// tier groups matches by kind. Every match of a lower tier ranks before any match
+// of a higher tier, whatever their scores.
+type tier int
+
+const (
+	// tierExact covers names equal to the query, ignoring case and spaces.
+	tierExact tier = iota
+	// tierMatch covers subsequence, chosung and Latin matches.
+	tierMatch
+	// tierTypo covers names only reachable by correcting typos.
+	tierTypo
+)
+
+func (t tier) String() string {
+	switch t {
+	case tierExact:
+		return "exact"
+	case tierTypo:
+		return "typo"
+	default:
+		return "match"
+	}
+}
+
+// candidate is a ticker that matched the query, with the keys used for ranking.
+type candidate struct {
+	index int
+	tier  tier
+	score int
+}
+
+func (c candidate) betterThan(o candidate) bool {
+	if c.tier != o.tier {
+		return c.tier < o.tier
+	}
+	return c.score > o.score
+}
+
+// matcher finds the tickers matching query. Within tierMatch, scores from different
+// matchers share the sahilm/fuzzy scale so they can be ranked together.
+type matcher func(tickers []models.Ticker, query string) []candidate
+
+var matchers = []matcher{
+	matchExact,
+	matchCode,
+	matchName,
+	matchChosung,
+	matchLatin,
+}
+
+// Match is a ticker that matched a query, with the keys it was ranked by.
+type Match struct {
+	Ticker models.Ticker
+	// Tier is "exact", "match" or "typo". Every match of an earlier tier ranks first.
+	Tier string
+	// Score orders matches within a tier, market-cap bonus included. Higher is better.
+	Score int
+}
+
+// FindTickers returns the tickers matching query, best match first. A ticker matched
+// by several matchers is ranked by its best match, blended with its market-cap rank;
+// ties keep the ticker list order.
 func FindTickers(tickers []models.Ticker, query string) []models.Ticker {
+	var results []models.Ticker
+	for _, m := range RankTickers(tickers, query) {
+		results = append(results, m.Ticker)
+	}
+	return results
+}
+
+// RankTickers is FindTickers with the tier and score of every match.
+func RankTickers(tickers []models.Ticker, query string) []Match {
 	if query == "" {
 		return nil
 	}
 
-	source := TickerSource(tickers)
-	matches := fuzzy.FindFrom(query, source)
+	best := make(map[int]candidate)
+	collect := func(m matcher) {
+		for _, c := range m(tickers, query) {
+			if prev, ok := best[c.index]; !ok || c.betterThan(prev) {
+				best[c.index] = c
+			}
+		}
+	}
+	for _, m := range matchers {
+		collect(m)
+	}
+	// Typos are only corrected when nothing matches the query as typed, so they
+	// never make a query ambiguous.
+	if len(best) == 0 {
+		collect(matchTypo)
+	}
 
-	sort.Sort(matches)
+	candidates := make([]candidate, 0, len(best))
+	for _, c := range best {
+		c.score += rankBonus(tickers[c.index].Rank)
+		candidates = append(candidates, c)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].tier != candidates[j].tier || candidates[i].score != candidates[j].score {
+			return candidates[i].betterThan(candidates[j])
+		}
+		return candidates[i].index < candidates[j].index
+	})
 
-	var results []models.Ticker
-	for _, match := range matches {
-		results = append(results, tickers[match.Index])
+	var results []Match
+	for _, c := range candidates {
+		results = append(results, Match{
+			Ticker: tickers[c.index],
+			Tier:   c.tier.String(),
+			Score:  c.score,
+		})
 	}
 
 	return results
 }
+
+// rankBonus favours large companies among similar matches: the largest ticker gains
+// rankBonusMax points, about as much as ten unmatched Hangul syllables cost, and the
+// bonus shrinks with the order of magnitude of the rank. Unranked tickers gain nothing.
+func rankBonus(rank int) int {
+	if rank <= 0 {
+		return 0
+	}
+	return max(0, rankBonusMax-int(rankBonusPerDecade*math.Log10(float64(rank))))
+}
+
+// IsExactMatch reports whether the ticker's Korean or English name equals query,
+// ignoring case and spaces.
+func IsExactMatch(t models.Ticker, query string) bool {
+	q := normalizeName(query)
+	return normalizeName(t.Name) == q || (t.EnglishName != "" && normalizeName(t.EnglishName) == q)
+}
+
+// matchExact finds tickers whose name equals the query.
+func matchExact(tickers []models.Ticker, query string) []candidate {
+	var candidates []candidate
+	for i, t := range tickers {
+		if IsExactMatch(t, query) {
+			candidates = append(candidates, candidate{index: i, tier: tierExact})
+		}
+	}
+	return candidates
+}
+
+// matchCode finds tickers whose code starts with a query beginning with a digit, so
+// "0059" lists 005930 and 005935. A complete code counts as an exact match.
+func matchCode(tickers []models.Ticker, query string) []candidate {
+	if query[0] < '0' || query[0] > '9' {
+		return nil
+	}
+
+	q := strings.ToUpper(query)
+	var candidates []candidate
+	for i, t := range tickers {
+		switch {
+		case t.Code == q:
+			candidates = append(candidates, candidate{index: i, tier: tierExact})
+		case strings.HasPrefix(t.Code, q):
+			candidates = append(candidates, candidate{index: i, tier: tierMatch, score: codePrefixScore})
+		}
+	}
+	return candidates
+}
+
+func normalizeName(s string) string {
+	return strings.ToLower(strings.ReplaceAll(s, " ", ""))
+}
+
+// matchName fuzzy-matches the query against ticker names.
+func matchName(tickers []models.Ticker, query string) []candidate {
+	return fromMatches(fuzzy.FindFromNoSort(query, TickerSource(tickers)))
+}
+
+// matchChosung handles queries containing standalone consonants such as "ㅅㅅㅈㅈ" or
+// "삼ㅈ". Names and query are both reduced to initial consonants for scoring, and
+// the full query is then checked so typed syllables must still match exactly.
+func matchChosung(tickers []models.Ticker, query string) []candidate {
+	if !hasConsonant(query) {
+		return nil
+	}
+
+	source := make(nameSource, len(tickers))
+	for i, t := range tickers {
+		source[i] = Chosung(t.Name)
+	}
+
+	var candidates []candidate
+	for _, c := range fromMatches(fuzzy.FindFromNoSort(Chosung(query), source)) {
+		if matchesChosung(query, tickers[c.index].Name) {
+			candidates = append(candidates, c)
+		}
+	}
+	return candidates
+}
+
+// matchLatin handles queries typed in Latin letters such as "samsung" or "kakao" by
+// matching the English listing name and the romanized Korean name.
+func matchLatin(tickers []models.Ticker, query string) []candidate {
+	if !isLatinQuery(query) {
+		return nil
+	}
+
+	english := make(nameSource, len(tickers))
+	romanized := make(nameSource, len(tickers))
+	for i, t := range tickers {
+		english[i] = t.EnglishName
+		romanized[i] = Romanize(t.Name)
+	}
+
+	candidates := fromMatches(fuzzy.FindFromNoSort(query, english))
+	return append(candidates, fromMatches(fuzzy.FindFromNoSort(query, romanized))...)
+}
+
+// matchTypo finds names within a few jamo edits of the query, so a single wrong vowel
+// or consonant (삼승전자, 카카오벵크) still finds the stock. The query may match a
+// prefix of the name; trailing jamo of the name are not counted as edits.
+func matchTypo(tickers []models.Ticker, query string) []candidate {
+	q := decompose(strings.ToLower(query))
+	if len(q) < minTypoQueryJamo {
+		return nil
+	}
+	allowed := max(1, len(q)/jamoPerTypo)
+
+	var candidates []candidate
+	for i, t := range tickers {
+		name := decompose(strings.ToLower(t.Name))
+		dist, end := prefixDistance(q, name)
+		if dist > allowed {
+			continue
+		}
+		candidates = append(candidates, candidate{
+			index: i,
+			tier:  tierTypo,
+			score: -dist*typoPenalty - (len(name) - end),
+		})
+	}
+	return candidates
+}
+
+func fromMatches(matches fuzzy.Matches) []candidate {
+	candidates := make([]candidate, 0, len(matches))
+	for _, m := range matches {
+		candidates = append(candidates, candidate{index: m.Index, tier: tierMatch, score: m.Score})
+	}
+	return candidates
+}
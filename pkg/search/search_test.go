@@ -39,6 +39,21 @@ func TestFindTickers(t *testing.T) {
 			// "삼전" in "삼성전기" matches '삼'(0) '전'(2).
 			// Same length. Stable sort preserves order?
 		},
+		{
+			name:     "Chosung query",
+			query:    "ㅅㅅㅈㅈ",
+			expected: []string{"삼성전자", "삼성전자우"},
+		},
+		{
+			name:     "Mixed syllable and chosung query",
+			query:    "삼ㅈ",
+			expected: []string{"삼성전자", "삼성전기", "삼성전자우"},
+		},
+		{
+			name:     "Chosung query keeps typed syllables exact",
+			query:    "카ㅋㅇ",
+			expected: []string{"카카오"},
+		},
 		{
 			name:     "No match",
 			query:    "XYZ",
@@ -71,3 +86,121 @@ func TestFindTickers(t *testing.T) {
 		})
 	}
 }
+
+func TestFindTickers_Latin(t *testing.T) {
+	tickers := []models.Ticker{
+		{Code: "005930", Name: "삼성전자", Market: "KOSPI", EnglishName: "SamsungElec"},
+		{Code: "000660", Name: "SK하이닉스", Market: "KOSPI", EnglishName: "SK hynix"},
+		{Code: "035720", Name: "카카오", Market: "KOSPI"},
+	}
+
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{"samsung", "삼성전자"},
+		{"hynix", "SK하이닉스"},
+		{"kakao", "카카오"},     // romanized, no English name available
+		{"samseong", "삼성전자"}, // romanized Korean name
+	}
+
+	for _, tt := range tests {
+		results := FindTickers(tickers, tt.query)
+		if len(results) == 0 || results[0].Name != tt.expected {
+			t.Errorf("FindTickers(%q) = %v, want %s first", tt.query, results, tt.expected)
+		}
+	}
+}
+
+func TestFindTickers_RankAndExact(t *testing.T) {
+	tickers := []models.Ticker{
+		{Code: "001", Name: "삼성출판사", Market: "KOSPI", Rank: 900},
+		{Code: "002", Name: "삼성SDI", Market: "KOSPI", Rank: 30},
+		{Code: "003", Name: "삼성전자", Market: "KOSPI", Rank: 1},
+		{Code: "004", Name: "카카오뱅크", Market: "KOSPI", Rank: 20},
+		{Code: "005", Name: "카카오", Market: "KOSPI", Rank: 50},
+		{Code: "006", Name: "NAVER", Market: "KOSPI", Rank: 15},
+	}
+
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		// 삼성SDI is the shorter name, but 삼성전자 is far larger.
+		{"삼성", []string{"삼성전자", "삼성SDI", "삼성출판사"}},
+		// Exact names win regardless of rank.
+		{"카카오", []string{"카카오", "카카오뱅크"}},
+		{"naver", []string{"NAVER"}},
+	}
+
+	for _, tt := range tests {
+		var names []string
+		for _, r := range FindTickers(tickers, tt.query) {
+			names = append(names, r.Name)
+		}
+		if len(names) > len(tt.expected) {
+			names = names[:len(tt.expected)]
+		}
+		if !reflect.DeepEqual(names, tt.expected) {
+			t.Errorf("FindTickers(%q) = %v, want %v", tt.query, names, tt.expected)
+		}
+	}
+}
+
+func TestFindTickers_CodePrefix(t *testing.T) {
+	tickers := []models.Ticker{
+		{Code: "005935", Name: "삼성전자우", Market: "KOSPI", Rank: 20},
+		{Code: "005930", Name: "삼성전자", Market: "KOSPI", Rank: 1},
+		{Code: "0059A0", Name: "가상종목", Market: "KOSDAQ"},
+		{Code: "035720", Name: "카카오", Market: "KOSPI"},
+	}
+
+	var codes []string
+	for _, r := range FindTickers(tickers, "0059") {
+		codes = append(codes, r.Code)
+	}
+	if want := []string{"005930", "005935", "0059A0"}; !reflect.DeepEqual(codes, want) {
+		t.Errorf("FindTickers(0059) = %v, want %v", codes, want)
+	}
+
+	if results := FindTickers(tickers, "0059a0"); len(results) == 0 || results[0].Code != "0059A0" {
+		t.Errorf("Expected a full code to match exactly, got %v", results)
+	}
+}
+
+func TestFindTickers_FuzzyMatchRanksBelowExact(t *testing.T) {
+	// 카카오뱅크 only matches 카카오 as a subsequence; its rank bonus must not lift it
+	// into the exact tier.
+	tickers := []models.Ticker{
+		{Code: "323410", Name: "카카오뱅크", Market: "KOSPI", Rank: 1},
+		{Code: "035720", Name: "카카오", Market: "KOSPI"},
+	}
+
+	results := FindTickers(tickers, "카카오")
+	if len(results) != 2 || results[0].Code != "035720" {
+		t.Errorf("Expected the exact match 카카오 before the fuzzy match, got %v", results)
+	}
+}
+
+func TestRankTickers(t *testing.T) {
+	tickers := []models.Ticker{
+		{Code: "323410", Name: "카카오뱅크", Market: "KOSPI"},
+		{Code: "035720", Name: "카카오", Market: "KOSPI"},
+		{Code: "005930", Name: "삼성전자", Market: "KOSPI"},
+	}
+
+	matches := RankTickers(tickers, "카카오")
+	if len(matches) != 2 {
+		t.Fatalf("Expected 2 matches, got %d", len(matches))
+	}
+	if matches[0].Ticker.Code != "035720" || matches[0].Tier != "exact" {
+		t.Errorf("Expected an exact match on 카카오 first, got %+v", matches[0])
+	}
+	if matches[1].Tier != "match" {
+		t.Errorf("Expected 카카오뱅크 in the match tier, got %+v", matches[1])
+	}
+
+	if got := RankTickers(tickers, "삼승전자"); len(got) != 1 || got[0].Tier != "typo" {
+		t.Errorf("Expected a single typo match, got %+v", got)
+	}
+}
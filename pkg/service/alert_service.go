@@ -0,0 +1,177 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/ericyhkim/juga/pkg/models"
+	"github.com/ericyhkim/juga/pkg/resolver"
+)
+
+type AlertRepository interface {
+	Load() error
+	Add(alert models.Alert) (models.Alert, error)
+	Remove(id int) (bool, error)
+	GetAll() []models.Alert
+	SaveState(alerts []models.Alert) error
+}
+
+// AlertHysteresis controls how far a value must retreat past the threshold before a
+// fired alert re-arms. PriceRatio applies to above/below alerts as a fraction of the
+// threshold; PercentPoints applies to change-percent alerts.
+type AlertHysteresis struct {
+	PriceRatio    float64
+	PercentPoints float64
+}
+
+type AlertService struct {
+	repo       AlertRepository
+	resolver   *resolver.Resolver
+	hysteresis AlertHysteresis
+}
+
+func NewAlertService(repo AlertRepository, res *resolver.Resolver, hysteresis AlertHysteresis) *AlertService {
+	return &AlertService{
+		repo:       repo,
+		resolver:   res,
+		hysteresis: hysteresis,
+	}
+}
+
+// Add registers an alert for a resolved stock. command is optional and overrides the
+// command given to the checker for this alert only.
+func (s *AlertService) Add(res resolver.ResolutionResult, condition models.AlertCondition, threshold float64, command string) (*models.Alert, error) {
+	if res.Status != resolver.StatusSuccess {
+		return nil, ErrInvalidTarget
+	}
+
+	switch condition {
+	case models.AlertAbove, models.AlertBelow, models.AlertChangePct:
+	default:
+		return nil, fmt.Errorf("%w: unknown condition '%s'", ErrInvalidAlert, condition)
+	}
+	if threshold <= 0 {
+		return nil, fmt.Errorf("%w: threshold must be positive", ErrInvalidAlert)
+	}
+
+	name := res.Name
+	if name == "" {
+		name = s.resolver.NameOf(res.Code)
+	}
+
+	alert, err := s.repo.Add(models.Alert{
+		Item:      res.Input,
+		Code:      res.Code,
+		Name:      name,
+		Condition: condition,
+		Threshold: threshold,
+		Command:   command,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save alert: %w", err)
+	}
+	return &alert, nil
+}
+
+func (s *AlertService) Remove(id int) error {
+	ok, err := s.repo.Remove(id)
+	if err != nil {
+		return fmt.Errorf("failed to save alerts: %w", err)
+	}
+	if !ok {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Reload reads the alerts again, picking up changes made by other commands.
+func (s *AlertService) Reload() error {
+	if err := s.repo.Load(); err != nil {
+		return fmt.Errorf("failed to reload alerts: %w", err)
+	}
+	return nil
+}
+
+func (s *AlertService) List() []models.Alert {
+	return s.repo.GetAll()
+}
+
+// Codes returns the distinct stock codes watched by at least one alert.
+func (s *AlertService) Codes() []string {
+	seen := make(map[string]bool)
+	var codes []string
+	for _, a := range s.repo.GetAll() {
+		if !seen[a.Code] {
+			seen[a.Code] = true
+			codes = append(codes, a.Code)
+		}
+	}
+	return codes
+}
+
+// Check evaluates every alert against fresh quotes and returns the alerts that
+// crossed their threshold. Trigger state is persisted so a restarted checker does
+// not fire the same crossing twice; alerts edited in the meantime are left as they are.
+func (s *AlertService) Check(stocks []models.Stock, now time.Time) ([]models.AlertEvent, error) {
+	byCode := make(map[string]models.Stock, len(stocks))
+	for _, st := range stocks {
+		byCode[st.Code] = st
+	}
+
+	alerts := s.repo.GetAll()
+	var events []models.AlertEvent
+	changed := false
+
+	for i := range alerts {
+		st, ok := byCode[alerts[i].Code]
+		if !ok {
+			continue
+		}
+
+		before := alerts[i].Triggered
+		if s.evaluate(&alerts[i], st) {
+			alerts[i].LastFired = now
+			events = append(events, models.AlertEvent{Alert: alerts[i], Stock: st})
+		}
+		if alerts[i].Triggered != before {
+			changed = true
+		}
+	}
+
+	if changed {
+		if err := s.repo.SaveState(alerts); err != nil {
+			return events, fmt.Errorf("failed to save alert state: %w", err)
+		}
+	}
+	return events, nil
+}
+
+// evaluate updates the trigger state of a against s and reports whether it fired.
+func (s *AlertService) evaluate(a *models.Alert, st models.Stock) bool {
+	var active, rearm bool
+
+	switch a.Condition {
+	case models.AlertAbove:
+		active = st.Price >= a.Threshold
+		rearm = st.Price < a.Threshold*(1-s.hysteresis.PriceRatio)
+	case models.AlertBelow:
+		active = st.Price <= a.Threshold
+		rearm = st.Price > a.Threshold*(1+s.hysteresis.PriceRatio)
+	case models.AlertChangePct:
+		move := math.Abs(st.ChangePercent)
+		active = move >= a.Threshold
+		rearm = move < a.Threshold-s.hysteresis.PercentPoints
+	default:
+		return false
+	}
+
+	if !a.Triggered && active {
+		a.Triggered = true
+		return true
+	}
+	if a.Triggered && rearm {
+		a.Triggered = false
+	}
+	return false
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ericyhkim/juga/pkg/diag"
+	"github.com/ericyhkim/juga/pkg/models"
+	"github.com/ericyhkim/juga/pkg/storage"
+)
+
+type memAlertRepo struct {
+	alerts []models.Alert
+	saves  int
+}
+
+func (r *memAlertRepo) Load() error { return nil }
+
+func (r *memAlertRepo) Add(a models.Alert) (models.Alert, error) {
+	a.ID = len(r.alerts) + 1
+	r.alerts = append(r.alerts, a)
+	return a, nil
+}
+
+func (r *memAlertRepo) Remove(id int) (bool, error) { return false, nil }
+
+func (r *memAlertRepo) GetAll() []models.Alert {
+	return append([]models.Alert(nil), r.alerts...)
+}
+
+func (r *memAlertRepo) SaveState(alerts []models.Alert) error {
+	r.alerts = alerts
+	r.saves++
+	return nil
+}
+
+func TestAlertService_FiresOncePerCrossing(t *testing.T) {
+	repo := &memAlertRepo{alerts: []models.Alert{
+		{ID: 1, Code: "005930", Condition: models.AlertAbove, Threshold: 80000},
+	}}
+	svc := NewAlertService(repo, nil, AlertHysteresis{PriceRatio: 0.01})
+
+	prices := []struct {
+		price float64
+		fire  bool
+	}{
+		{79000, false},
+		{80000, true},  // crosses
+		{81000, false}, // still above
+		{79500, false}, // inside the hysteresis band (> 79,200), stays triggered
+		{80500, false}, // never re-armed, must not fire again
+		{79000, false}, // re-arms
+		{80100, true},  // second crossing
+	}
+
+	for i, p := range prices {
+		events, err := svc.Check([]models.Stock{{Code: "005930", Price: p.price}}, time.Now())
+		if err != nil {
+			t.Fatalf("step %d: Check() returned error: %v", i, err)
+		}
+		if fired := len(events) == 1; fired != p.fire {
+			t.Errorf("step %d (price %.0f): expected fire=%v, got %v", i, p.price, p.fire, fired)
+		}
+	}
+}
+
+func TestAlertService_ChangePercentBothDirections(t *testing.T) {
+	repo := &memAlertRepo{alerts: []models.Alert{
+		{ID: 1, Code: "000660", Condition: models.AlertChangePct, Threshold: 5},
+	}}
+	svc := NewAlertService(repo, nil, AlertHysteresis{PercentPoints: 0.5})
+
+	check := func(pct float64) int {
+		events, _ := svc.Check([]models.Stock{{Code: "000660", ChangePercent: pct}}, time.Now())
+		return len(events)
+	}
+
+	if n := check(-5.2); n != 1 {
+		t.Errorf("Expected a 5.2%% drop to fire, got %d events", n)
+	}
+	if n := check(-4.7); n != 0 {
+		t.Errorf("Expected no event inside the band, got %d", n)
+	}
+	if n := check(-5.5); n != 0 {
+		t.Errorf("Expected no re-fire without re-arming, got %d", n)
+	}
+	if n := check(1.0); n != 0 {
+		t.Errorf("Expected re-arm without firing, got %d", n)
+	}
+	if n := check(6.0); n != 1 {
+		t.Errorf("Expected a 6%% rise to fire, got %d events", n)
+	}
+}
+
+func TestAlertService_SavesOnlyOnStateChange(t *testing.T) {
+	repo := &memAlertRepo{alerts: []models.Alert{
+		{ID: 1, Code: "005930", Condition: models.AlertBelow, Threshold: 70000},
+	}}
+	svc := NewAlertService(repo, nil, AlertHysteresis{PriceRatio: 0.01})
+
+	svc.Check([]models.Stock{{Code: "005930", Price: 75000}}, time.Now())
+	if repo.saves != 0 {
+		t.Errorf("Expected no save while idle, got %d", repo.saves)
+	}
+
+	now := time.Date(2026, 10, 18, 10, 0, 0, 0, time.Local)
+	events, _ := svc.Check([]models.Stock{{Code: "005930", Price: 69000}}, now)
+	if repo.saves != 1 || len(events) != 1 {
+		t.Fatalf("Expected one save and one event, got %d saves and %d events", repo.saves, len(events))
+	}
+	if !repo.alerts[0].Triggered || !repo.alerts[0].LastFired.Equal(now) {
+		t.Errorf("Expected persisted trigger state, got %+v", repo.alerts[0])
+	}
+}
+
+func TestAlertService_KeepsAlertsEditedBetweenChecks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "alerts.json")
+	repo := storage.NewAlertRepository(path, diag.NewNopLogger())
+	first, _ := repo.Add(models.Alert{Code: "005930", Condition: models.AlertAbove, Threshold: 80000})
+	svc := NewAlertService(repo, nil, AlertHysteresis{PriceRatio: 0.01})
+
+	if events, _ := svc.Check([]models.Stock{{Code: "005930", Price: 81000}}, time.Now()); len(events) != 1 {
+		t.Fatalf("Expected the first check to fire, got %d events", len(events))
+	}
+
+	// Another command edits the file while the checker runs.
+	other := storage.NewAlertRepository(path, diag.NewNopLogger())
+	if err := other.Load(); err != nil {
+		t.Fatal(err)
+	}
+	added, _ := other.Add(models.Alert{Code: "000660", Condition: models.AlertBelow, Threshold: 100000})
+
+	// Re-arming the first alert saves its state without reloading first.
+	if _, err := svc.Check([]models.Stock{{Code: "005930", Price: 70000}}, time.Now()); err != nil {
+		t.Fatalf("Check() returned error: %v", err)
+	}
+
+	stored := storage.NewAlertRepository(path, diag.NewNopLogger())
+	if err := stored.Load(); err != nil {
+		t.Fatal(err)
+	}
+	alerts := stored.GetAll()
+	if len(alerts) != 2 || alerts[1].ID != added.ID {
+		t.Fatalf("Expected the added alert to be kept, got %+v", alerts)
+	}
+	if alerts[0].ID != first.ID || alerts[0].Triggered {
+		t.Errorf("Expected the first alert to be re-armed, got %+v", alerts[0])
+	}
+
+	if err := svc.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if codes := svc.Codes(); len(codes) != 2 {
+		t.Errorf("Expected the reloaded alerts to watch 2 codes, got %v", codes)
+	}
+}
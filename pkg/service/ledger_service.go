@@ -0,0 +1,241 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/ericyhkim/juga/pkg/models"
+	"github.com/ericyhkim/juga/pkg/resolver"
+)
+
+// quantityEpsilon absorbs floating point noise when comparing share counts.
+const quantityEpsilon = 1e-9
+
+type LedgerRepository interface {
+	Append(portfolio string, tx models.Transaction) error
+	Transactions(portfolio string) []models.Transaction
+	Settings(portfolio string) models.LedgerSettings
+	SetSettings(portfolio string, settings models.LedgerSettings) error
+}
+
+type LedgerService struct {
+	repo       LedgerRepository
+	portfolios *PortfolioService
+	resolver   *resolver.Resolver
+}
+
+func NewLedgerService(repo LedgerRepository, portfolios *PortfolioService, res *resolver.Resolver) *LedgerService {
+	portfolios.ledger = repo
+	return &LedgerService{
+		repo:       repo,
+		portfolios: portfolios,
+		resolver:   res,
+	}
+}
+
+// Record appends a transaction to the portfolio's ledger and updates the matching
+// portfolio holding with the position derived from the whole ledger.
+func (s *LedgerService) Record(
+	portfolio string,
+	txType models.TransactionType,
+	res resolver.ResolutionResult,
+	quantity, price float64,
+	date time.Time,
+) (*LedgerOpResult, error) {
+	if res.Status != resolver.StatusSuccess {
+		return nil, ErrInvalidTarget
+	}
+	if quantity <= 0 || price < 0 {
+		return nil, ErrInvalidTransaction
+	}
+
+	name := res.Name
+	if name == "" {
+		name = s.resolver.NameOf(res.Code)
+	}
+
+	settings := s.repo.Settings(portfolio)
+	tx := models.Transaction{
+		Type:     txType,
+		Date:     date,
+		Item:     res.Input,
+		Code:     res.Code,
+		Name:     name,
+		Quantity: quantity,
+		Price:    price,
+	}
+
+	switch txType {
+	case models.TxBuy:
+		tx.Fee = truncateWon(tx.Amount() * settings.FeeRate)
+	case models.TxSell:
+		tx.Fee = truncateWon(tx.Amount() * settings.FeeRate)
+		tx.Tax = truncateWon(tx.Amount() * settings.TaxRate)
+	case models.TxDividend:
+	default:
+		return nil, fmt.Errorf("%w: unknown type '%s'", ErrInvalidTransaction, txType)
+	}
+
+	report, err := BuildLedgerReport(append(s.repo.Transactions(portfolio), tx))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Append(portfolio, tx); err != nil {
+		return nil, fmt.Errorf("failed to save transaction: %w", err)
+	}
+
+	var pos models.LedgerPosition
+	for _, p := range report.Positions {
+		if p.Code == tx.Code {
+			pos = p
+			break
+		}
+	}
+
+	if txType != models.TxDividend {
+		if err := s.syncHolding(portfolio, tx, pos); err != nil {
+			return nil, err
+		}
+	}
+
+	return &LedgerOpResult{
+		Portfolio:   portfolio,
+		Transaction: tx,
+		Position:    pos,
+	}, nil
+}
+
+func (s *LedgerService) Transactions(portfolio string) []models.Transaction {
+	txs := s.repo.Transactions(portfolio)
+	sortTransactions(txs)
+	return txs
+}
+
+func (s *LedgerService) Report(portfolio string) (*models.LedgerReport, error) {
+	txs := s.repo.Transactions(portfolio)
+	if len(txs) == 0 {
+		return nil, ErrNotFound
+	}
+	return BuildLedgerReport(txs)
+}
+
+func (s *LedgerService) Settings(portfolio string) models.LedgerSettings {
+	return s.repo.Settings(portfolio)
+}
+
+func (s *LedgerService) SetSettings(portfolio string, settings models.LedgerSettings) error {
+	if settings.FeeRate < 0 || settings.TaxRate < 0 {
+		return ErrInvalidTransaction
+	}
+	return s.repo.SetSettings(portfolio, settings)
+}
+
+// syncHolding writes a derived position into the portfolio. It updates the entry that was
+// used to record the transaction, or any entry resolving to the same code, and appends
+// a new entry otherwise. Manual edits of these positions are refused by PortfolioService.
+func (s *LedgerService) syncHolding(portfolio string, tx models.Transaction, pos models.LedgerPosition) error {
+	item := tx.Item
+	if holdings, err := s.portfolios.GetHoldings(portfolio); err == nil {
+		for _, h := range holdings {
+			if h.Item == tx.Item {
+				item = h.Item
+				break
+			}
+			if res := s.resolver.Lookup(h.Item); res.Status == resolver.StatusSuccess && res.Code == tx.Code {
+				item = h.Item
+				break
+			}
+		}
+	}
+
+	if err := s.portfolios.syncPosition(portfolio, item, pos.Quantity, pos.AvgPrice); err != nil {
+		return fmt.Errorf("failed to update portfolio position: %w", err)
+	}
+	return nil
+}
+
+// BuildLedgerReport replays transactions in date order using average-cost accounting.
+// Buy fees are added to the cost basis; sell fees and taxes reduce the realized gain.
+func BuildLedgerReport(txs []models.Transaction) (*models.LedgerReport, error) {
+	sorted := make([]models.Transaction, len(txs))
+	copy(sorted, txs)
+	sortTransactions(sorted)
+
+	var order []string
+	byCode := make(map[string]*models.LedgerPosition)
+
+	for _, tx := range sorted {
+		pos, ok := byCode[tx.Code]
+		if !ok {
+			pos = &models.LedgerPosition{Code: tx.Code, Item: tx.Item}
+			byCode[tx.Code] = pos
+			order = append(order, tx.Code)
+		}
+		if tx.Name != "" {
+			pos.Name = tx.Name
+		}
+
+		switch tx.Type {
+		case models.TxBuy:
+			cost := pos.Quantity*pos.AvgPrice + tx.Amount() + tx.Fee
+			pos.Quantity += tx.Quantity
+			pos.AvgPrice = cost / pos.Quantity
+		case models.TxSell:
+			if tx.Quantity > pos.Quantity+quantityEpsilon {
+				return nil, fmt.Errorf("%w: selling %g of %s on %s but only %g held",
+					ErrInsufficientQuantity, tx.Quantity, displayName(tx), tx.Date.Format("2006-01-02"), pos.Quantity)
+			}
+			pos.RealizedGain += tx.Amount() - tx.Fee - tx.Tax - tx.Quantity*pos.AvgPrice
+			pos.Quantity -= tx.Quantity
+			if pos.Quantity < quantityEpsilon {
+				pos.Quantity = 0
+				pos.AvgPrice = 0
+			}
+		case models.TxDividend:
+			pos.DividendIncome += tx.Amount()
+		}
+
+		pos.Fees += tx.Fee
+		pos.Taxes += tx.Tax
+	}
+
+	report := &models.LedgerReport{}
+	for _, code := range order {
+		pos := byCode[code]
+		report.Positions = append(report.Positions, *pos)
+		report.RealizedGain += pos.RealizedGain
+		report.DividendIncome += pos.DividendIncome
+		report.Fees += pos.Fees
+		report.Taxes += pos.Taxes
+	}
+
+	return report, nil
+}
+
+// sortTransactions orders transactions by trade day. Transactions of the same day
+// keep the order they were recorded in, whatever their time of day.
+func sortTransactions(txs []models.Transaction) {
+	sort.SliceStable(txs, func(i, j int) bool {
+		return tradeDay(txs[i].Date) < tradeDay(txs[j].Date)
+	})
+}
+
+func tradeDay(t time.Time) string {
+	return t.In(time.Local).Format("2006-01-02")
+}
+
+// truncateWon drops fractions of a won, as brokers do, while tolerating float noise
+// (700,000 * 0.00015 must yield 105, not 104).
+func truncateWon(v float64) float64 {
+	return math.Floor(math.Round(v*1e6) / 1e6)
+}
+
+func displayName(tx models.Transaction) string {
+	if tx.Name != "" {
+		return tx.Name
+	}
+	return tx.Code
+}
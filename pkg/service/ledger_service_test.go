@@ -0,0 +1,207 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ericyhkim/juga/pkg/diag"
+	"github.com/ericyhkim/juga/pkg/models"
+	"github.com/ericyhkim/juga/pkg/resolver"
+	"github.com/ericyhkim/juga/pkg/storage"
+)
+
+func day(d int) time.Time {
+	return time.Date(2024, 1, d, 0, 0, 0, 0, time.Local)
+}
+
+func TestBuildLedgerReport_AverageCost(t *testing.T) {
+	txs := []models.Transaction{
+		{Type: models.TxBuy, Date: day(2), Code: "005930", Quantity: 10, Price: 70000, Fee: 100},
+		{Type: models.TxBuy, Date: day(3), Code: "005930", Quantity: 10, Price: 80000, Fee: 100},
+		// Recorded out of order: must be replayed after both buys.
+		{Type: models.TxSell, Date: day(10), Code: "005930", Quantity: 5, Price: 90000, Fee: 50, Tax: 900},
+		{Type: models.TxDividend, Date: day(5), Code: "005930", Quantity: 20, Price: 361},
+	}
+
+	report, err := BuildLedgerReport(txs)
+	if err != nil {
+		t.Fatalf("BuildLedgerReport() returned error: %v", err)
+	}
+
+	if len(report.Positions) != 1 {
+		t.Fatalf("Expected 1 position, got %d", len(report.Positions))
+	}
+	pos := report.Positions[0]
+
+	// Cost basis: 700,000 + 100 + 800,000 + 100 = 1,500,200 over 20 shares
+	if math.Abs(pos.AvgPrice-75010) > 1e-6 {
+		t.Errorf("Expected AvgPrice 75010, got %f", pos.AvgPrice)
+	}
+	if pos.Quantity != 15 {
+		t.Errorf("Expected Quantity 15, got %f", pos.Quantity)
+	}
+
+	// 450,000 - 50 - 900 - 5*75,010
+	if math.Abs(report.RealizedGain-74000) > 1e-6 {
+		t.Errorf("Expected RealizedGain 74000, got %f", report.RealizedGain)
+	}
+	if report.DividendIncome != 7220 {
+		t.Errorf("Expected DividendIncome 7220, got %f", report.DividendIncome)
+	}
+	if report.Fees != 250 || report.Taxes != 900 {
+		t.Errorf("Expected Fees 250 / Taxes 900, got %f / %f", report.Fees, report.Taxes)
+	}
+}
+
+func TestBuildLedgerReport_Oversell(t *testing.T) {
+	txs := []models.Transaction{
+		{Type: models.TxBuy, Date: day(2), Code: "005930", Quantity: 1, Price: 70000},
+		{Type: models.TxSell, Date: day(3), Code: "005930", Quantity: 2, Price: 70000},
+	}
+
+	if _, err := BuildLedgerReport(txs); !errors.Is(err, ErrInsufficientQuantity) {
+		t.Errorf("Expected ErrInsufficientQuantity, got %v", err)
+	}
+}
+
+func TestBuildLedgerReport_ClosedPosition(t *testing.T) {
+	txs := []models.Transaction{
+		{Type: models.TxBuy, Date: day(2), Code: "035720", Quantity: 3, Price: 50000},
+		{Type: models.TxSell, Date: day(3), Code: "035720", Quantity: 3, Price: 40000},
+	}
+
+	report, err := BuildLedgerReport(txs)
+	if err != nil {
+		t.Fatalf("BuildLedgerReport() returned error: %v", err)
+	}
+
+	pos := report.Positions[0]
+	if pos.Quantity != 0 || pos.AvgPrice != 0 {
+		t.Errorf("Expected closed position, got %+v", pos)
+	}
+	if report.RealizedGain != -30000 {
+		t.Errorf("Expected RealizedGain -30000, got %f", report.RealizedGain)
+	}
+}
+
+func TestBuildLedgerReport_SameDayKeepsRecordingOrder(t *testing.T) {
+	// A buy recorded in the afternoon, then a sale dated the same day at midnight.
+	txs := []models.Transaction{
+		{Type: models.TxBuy, Date: day(2).Add(15 * time.Hour), Code: "005930", Quantity: 10, Price: 70000},
+		{Type: models.TxSell, Date: day(2), Code: "005930", Quantity: 10, Price: 71000},
+	}
+
+	report, err := BuildLedgerReport(txs)
+	if err != nil {
+		t.Fatalf("BuildLedgerReport() returned error: %v", err)
+	}
+	if report.RealizedGain != 10000 {
+		t.Errorf("Expected RealizedGain 10000, got %f", report.RealizedGain)
+	}
+}
+
+func newTestLedgerService(t *testing.T, aliases map[string]string, settings models.LedgerSettings) (*LedgerService, *PortfolioService) {
+	t.Helper()
+	res, portfolioRepo := newTestResolver(t, aliases)
+	portfolios := NewPortfolioService(portfolioRepo)
+	ledger := storage.NewLedgerRepository(filepath.Join(t.TempDir(), "ledger.json"), settings, diag.NewNopLogger())
+	return NewLedgerService(ledger, portfolios, res), portfolios
+}
+
+var samsung = resolver.ResolutionResult{Input: "005930", Code: "005930", Name: "삼성전자", Status: resolver.StatusSuccess}
+
+func TestLedgerService_RecordTruncatesFeesAndTaxes(t *testing.T) {
+	s, _ := newTestLedgerService(t, nil, models.LedgerSettings{FeeRate: 0.00015, TaxRate: 0.0018})
+
+	buy, err := s.Record("tech", models.TxBuy, samsung, 10, 70000, day(2))
+	if err != nil {
+		t.Fatalf("Record(buy) returned error: %v", err)
+	}
+	// 700,000 * 0.00015 = 105 despite float noise
+	if buy.Transaction.Fee != 105 || buy.Transaction.Tax != 0 {
+		t.Errorf("Expected fee 105 and no tax, got %g and %g", buy.Transaction.Fee, buy.Transaction.Tax)
+	}
+
+	sell, err := s.Record("tech", models.TxSell, samsung, 3, 71230, day(3))
+	if err != nil {
+		t.Fatalf("Record(sell) returned error: %v", err)
+	}
+	// 213,690 * 0.00015 = 32.05; 213,690 * 0.0018 = 384.64
+	if sell.Transaction.Fee != 32 || sell.Transaction.Tax != 384 {
+		t.Errorf("Expected fee 32 and tax 384, got %g and %g", sell.Transaction.Fee, sell.Transaction.Tax)
+	}
+	if sell.Position.Quantity != 7 {
+		t.Errorf("Expected 7 shares left, got %g", sell.Position.Quantity)
+	}
+}
+
+func TestLedgerService_RecordOversell(t *testing.T) {
+	s, portfolios := newTestLedgerService(t, nil, models.LedgerSettings{})
+
+	if _, err := s.Record("tech", models.TxBuy, samsung, 5, 70000, day(2)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Record("tech", models.TxSell, samsung, 6, 80000, day(3)); !errors.Is(err, ErrInsufficientQuantity) {
+		t.Fatalf("Expected ErrInsufficientQuantity, got %v", err)
+	}
+
+	if txs := s.Transactions("tech"); len(txs) != 1 {
+		t.Errorf("Expected the sell not to be recorded, got %d transactions", len(txs))
+	}
+	holdings, _ := portfolios.GetHoldings("tech")
+	if len(holdings) != 1 || holdings[0].Quantity != 5 {
+		t.Errorf("Expected the holding to keep 5 shares, got %+v", holdings)
+	}
+}
+
+func TestLedgerService_RecordSyncsHolding(t *testing.T) {
+	s, portfolios := newTestLedgerService(t, map[string]string{"sam": "005930"}, models.LedgerSettings{})
+
+	if _, err := portfolios.CreatePortfolio("tech", []string{"sam", "000660"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Record("tech", models.TxBuy, samsung, 10, 70000, day(2)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Record("tech", models.TxBuy, samsung, 10, 80000, day(3)); err != nil {
+		t.Fatal(err)
+	}
+
+	holdings, _ := portfolios.GetHoldings("tech")
+	if len(holdings) != 2 {
+		t.Fatalf("Expected the position to update the existing alias entry, got %+v", holdings)
+	}
+	if h := holdings[0]; h.Item != "sam" || h.Quantity != 20 || h.AvgPrice != 75000 {
+		t.Errorf("Expected sam -> 20 @ 75000, got %+v", h)
+	}
+}
+
+func TestLedgerService_RefusesManualPositionEdits(t *testing.T) {
+	s, portfolios := newTestLedgerService(t, nil, models.LedgerSettings{})
+
+	if _, err := s.Record("tech", models.TxBuy, samsung, 10, 70000, day(2)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := portfolios.SetPosition("tech", "005930", 50, 60000); !errors.Is(err, ErrLedgerManaged) {
+		t.Errorf("SetPosition: expected ErrLedgerManaged, got %v", err)
+	}
+	if _, err := portfolios.CreatePortfolio("tech", []string{"000660"}); !errors.Is(err, ErrLedgerManaged) {
+		t.Errorf("CreatePortfolio: expected ErrLedgerManaged, got %v", err)
+	}
+	if _, err := portfolios.ParseAndSave("tech", "005930 | 50 | 60000\n"); !errors.Is(err, ErrLedgerManaged) {
+		t.Errorf("ParseAndSave: expected ErrLedgerManaged, got %v", err)
+	}
+
+	// Edits that leave positions alone are still allowed.
+	holdings, _ := portfolios.GetHoldings("tech")
+	if _, err := portfolios.ParseAndSave("tech", portfolios.FormatForEditing(holdings)+"000660\n"); err != nil {
+		t.Errorf("ParseAndSave: unexpected error adding a watch-only item: %v", err)
+	}
+	if _, err := portfolios.SetPosition("other", "005930", 1, 70000); err != nil {
+		t.Errorf("SetPosition: unexpected error on a portfolio without ledger: %v", err)
+	}
+}
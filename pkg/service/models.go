@@ -8,9 +8,15 @@ import (
 )
 
 var (
-	ErrInvalidTarget = errors.New("could not resolve target to a valid stock")
-	ErrReservedName  = errors.New("nickname cannot be a valid stock code")
-	ErrNotFound      = errors.New("resource not found")
+	ErrInvalidTarget        = errors.New("could not resolve target to a valid stock")
+	ErrReservedName         = errors.New("nickname cannot be a valid stock code")
+	ErrNotFound             = errors.New("resource not found")
+	ErrInvalidPosition      = errors.New("quantity and average price must not be negative")
+	ErrInvalidTransaction   = errors.New("invalid transaction")
+	ErrInsufficientQuantity = errors.New("insufficient quantity")
+	ErrInvalidAlert         = errors.New("invalid alert")
+	ErrDuplicateItem        = errors.New("item already in portfolio")
+	ErrLedgerManaged        = errors.New("positions of this portfolio are derived from its transaction ledger")
 )
 
 // AliasOpResult represents the outcome of an alias management operation.
@@ -38,3 +44,10 @@ type StockFetchResult struct {
 type TickerUpdateResult struct {
 	Count int
 }
+
+// LedgerOpResult represents the outcome of recording a transaction.
+type LedgerOpResult struct {
+	Portfolio   string
+	Transaction models.Transaction
+	Position    models.LedgerPosition
+}
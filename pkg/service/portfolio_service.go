@@ -2,18 +2,26 @@ package service
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+
+	"github.com/ericyhkim/juga/pkg/models"
+	"github.com/ericyhkim/juga/pkg/resolver"
 )
 
 type PortfolioRepository interface {
-	Add(name string, items []string) error
+	Add(name string, holdings []models.Holding) error
 	Remove(name string) error
 	Get(name string) ([]string, bool)
-	GetAll() map[string][]string
+	GetHoldings(name string) ([]models.Holding, bool)
+	GetAll() map[string][]models.Holding
 }
 
 type PortfolioService struct {
 	repo PortfolioRepository
+	// ledger is attached by NewLedgerService. Positions of portfolios with
+	// transactions are derived from the ledger and cannot be edited by hand.
+	ledger LedgerRepository
 }
 
 func NewPortfolioService(repo PortfolioRepository) *PortfolioService {
@@ -22,14 +30,84 @@ func NewPortfolioService(repo PortfolioRepository) *PortfolioService {
 	}
 }
 
+// CreatePortfolio creates or overwrites a portfolio with the given inputs.
+// Positions already recorded for an input that is kept are preserved.
 func (s *PortfolioService) CreatePortfolio(name string, items []string) (*PortfolioOpResult, error) {
-	if err := s.repo.Add(name, items); err != nil {
-		return nil, fmt.Errorf("failed to save portfolio: %w", err)
+	existing, _ := s.repo.GetHoldings(name)
+	byItem := make(map[string]models.Holding, len(existing))
+	for _, h := range existing {
+		byItem[h.Item] = h
 	}
-	return &PortfolioOpResult{
-		Name:  name,
-		Count: len(items),
-	}, nil
+
+	holdings := make([]models.Holding, 0, len(items))
+	for _, item := range items {
+		if h, ok := byItem[item]; ok {
+			holdings = append(holdings, h)
+		} else {
+			holdings = append(holdings, models.Holding{Item: item})
+		}
+	}
+
+	return s.saveEdited(name, holdings)
+}
+
+// SetPosition records the quantity and average buy price of an item, appending it
+// to the portfolio (which is created if needed) when not present yet.
+// A zero quantity turns the entry back into a watch-only item.
+func (s *PortfolioService) SetPosition(name, item string, quantity, avgPrice float64) (*PortfolioOpResult, error) {
+	holdings, err := s.withPosition(name, item, quantity, avgPrice)
+	if err != nil {
+		return nil, err
+	}
+	return s.saveEdited(name, holdings)
+}
+
+// syncPosition is SetPosition for positions derived from the ledger.
+func (s *PortfolioService) syncPosition(name, item string, quantity, avgPrice float64) error {
+	holdings, err := s.withPosition(name, item, quantity, avgPrice)
+	if err != nil {
+		return err
+	}
+	_, err = s.saveHoldings(name, holdings)
+	return err
+}
+
+func (s *PortfolioService) withPosition(name, item string, quantity, avgPrice float64) ([]models.Holding, error) {
+	if quantity < 0 || avgPrice < 0 {
+		return nil, ErrInvalidPosition
+	}
+	if quantity == 0 {
+		avgPrice = 0
+	}
+
+	existing, _ := s.repo.GetHoldings(name)
+	holdings := make([]models.Holding, len(existing))
+	copy(holdings, existing)
+
+	found := false
+	for i := range holdings {
+		if holdings[i].Item == item {
+			holdings[i].Quantity = quantity
+			holdings[i].AvgPrice = avgPrice
+			found = true
+			break
+		}
+	}
+	if !found {
+		holdings = append(holdings, models.Holding{Item: item, Quantity: quantity, AvgPrice: avgPrice})
+	}
+	return holdings, nil
+}
+
+// AddItem appends an input to a portfolio, creating the portfolio if needed.
+func (s *PortfolioService) AddItem(name, item string) (*PortfolioOpResult, error) {
+	holdings, _ := s.repo.GetHoldings(name)
+	for _, h := range holdings {
+		if h.Item == item {
+			return nil, ErrDuplicateItem
+		}
+	}
+	return s.saveHoldings(name, append(holdings, models.Holding{Item: item}))
 }
 
 func (s *PortfolioService) GetPortfolio(name string) ([]string, error) {
@@ -40,6 +118,14 @@ func (s *PortfolioService) GetPortfolio(name string) ([]string, error) {
 	return items, nil
 }
 
+func (s *PortfolioService) GetHoldings(name string) ([]models.Holding, error) {
+	holdings, ok := s.repo.GetHoldings(name)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return holdings, nil
+}
+
 func (s *PortfolioService) RemovePortfolio(name string) error {
 	if _, ok := s.repo.Get(name); !ok {
 		return ErrNotFound
@@ -47,20 +133,166 @@ func (s *PortfolioService) RemovePortfolio(name string) error {
 	return s.repo.Remove(name)
 }
 
-func (s *PortfolioService) ListPortfolios() map[string][]string {
+func (s *PortfolioService) ListPortfolios() map[string][]models.Holding {
 	return s.repo.GetAll()
 }
 
+// FormatForEditing renders holdings in the line format understood by ParseAndSave.
+func (s *PortfolioService) FormatForEditing(holdings []models.Holding) string {
+	var sb strings.Builder
+	for _, h := range holdings {
+		if h.HasPosition() {
+			sb.WriteString(fmt.Sprintf("%s | %s | %s\n", h.Item, formatFloat(h.Quantity), formatFloat(h.AvgPrice)))
+		} else {
+			sb.WriteString(h.Item + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// ParseAndSave parses editor content and overwrites the portfolio with it.
+// Each line is either "<item>" or "<item> | <quantity> | <avg_price>".
 func (s *PortfolioService) ParseAndSave(name, content string) (*PortfolioOpResult, error) {
-	var newItems []string
+	var holdings []models.Holding
 	lines := strings.Split(content, "\n")
-	for _, line := range lines {
+	for i, line := range lines {
 		line = strings.TrimSpace(line)
 		if line == "" || strings.HasPrefix(line, "#") {
 			continue
 		}
-		newItems = append(newItems, line)
+
+		h, err := parseHoldingLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		holdings = append(holdings, h)
 	}
 
-	return s.CreatePortfolio(name, newItems)
+	return s.saveEdited(name, holdings)
+}
+
+// BuildPositions pairs the holdings of a portfolio with fetched quotes.
+// Items resolving to the same code are merged using a quantity-weighted average price.
+// Items are resolved as in results, which may have dropped items resolving to a code
+// already listed; those are looked up with res. Positions follow the order of stocks.
+func BuildPositions(holdings []models.Holding, results []resolver.ResolutionResult, stocks []models.Stock, res *resolver.Resolver) []models.Position {
+	codeByItem := make(map[string]string, len(results))
+	for _, r := range results {
+		if r.Status == resolver.StatusSuccess {
+			codeByItem[r.Input] = r.Code
+		}
+	}
+
+	merged := make(map[string]models.Holding)
+	for _, h := range holdings {
+		code, ok := codeByItem[h.Item]
+		if !ok {
+			found := res.Lookup(h.Item)
+			if found.Status != resolver.StatusSuccess {
+				continue
+			}
+			code = found.Code
+		}
+		acc := merged[code]
+		if total := acc.Quantity + h.Quantity; total != 0 {
+			acc.AvgPrice = (acc.Quantity*acc.AvgPrice + h.Quantity*h.AvgPrice) / total
+		}
+		acc.Quantity += h.Quantity
+		merged[code] = acc
+	}
+
+	positions := make([]models.Position, 0, len(stocks))
+	for _, stock := range stocks {
+		h := merged[stock.Code]
+		positions = append(positions, models.Position{
+			Stock:    stock,
+			Quantity: h.Quantity,
+			AvgPrice: h.AvgPrice,
+		})
+	}
+	return positions
+}
+
+// saveEdited saves holdings edited by hand, refusing to change the positions of a
+// portfolio backed by a transaction ledger.
+func (s *PortfolioService) saveEdited(name string, holdings []models.Holding) (*PortfolioOpResult, error) {
+	if s.ledger != nil && len(s.ledger.Transactions(name)) > 0 {
+		existing, _ := s.repo.GetHoldings(name)
+		if positionsChanged(existing, holdings) {
+			return nil, ErrLedgerManaged
+		}
+	}
+	return s.saveHoldings(name, holdings)
+}
+
+func (s *PortfolioService) saveHoldings(name string, holdings []models.Holding) (*PortfolioOpResult, error) {
+	if err := s.repo.Add(name, holdings); err != nil {
+		return nil, fmt.Errorf("failed to save portfolio: %w", err)
+	}
+	return &PortfolioOpResult{
+		Name:  name,
+		Count: len(holdings),
+	}, nil
+}
+
+// positionsChanged reports whether next records other positions than prev.
+func positionsChanged(prev, next []models.Holding) bool {
+	before := positionsByItem(prev)
+	after := positionsByItem(next)
+	if len(before) != len(after) {
+		return true
+	}
+	for item, h := range before {
+		if a, ok := after[item]; !ok || a.Quantity != h.Quantity || a.AvgPrice != h.AvgPrice {
+			return true
+		}
+	}
+	return false
+}
+
+func positionsByItem(holdings []models.Holding) map[string]models.Holding {
+	byItem := make(map[string]models.Holding)
+	for _, h := range holdings {
+		if h.HasPosition() {
+			byItem[h.Item] = h
+		}
+	}
+	return byItem
+}
+
+func parseHoldingLine(line string) (models.Holding, error) {
+	parts := strings.Split(line, "|")
+	item := strings.TrimSpace(parts[0])
+	if item == "" {
+		return models.Holding{}, fmt.Errorf("missing item")
+	}
+	if len(parts) == 1 {
+		return models.Holding{Item: item}, nil
+	}
+	if len(parts) != 3 {
+		return models.Holding{}, fmt.Errorf("expected '<item> | <quantity> | <avg_price>', got %q", line)
+	}
+
+	quantity, err := ParseAmount(parts[1])
+	if err != nil {
+		return models.Holding{}, fmt.Errorf("invalid quantity %q", strings.TrimSpace(parts[1]))
+	}
+	avgPrice, err := ParseAmount(parts[2])
+	if err != nil {
+		return models.Holding{}, fmt.Errorf("invalid average price %q", strings.TrimSpace(parts[2]))
+	}
+	if quantity < 0 || avgPrice < 0 {
+		return models.Holding{}, ErrInvalidPosition
+	}
+
+	return models.Holding{Item: item, Quantity: quantity, AvgPrice: avgPrice}, nil
+}
+
+// ParseAmount parses a user-supplied number, accepting thousands separators (e.g. "70,000").
+func ParseAmount(s string) (float64, error) {
+	return strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(s), ",", ""), 64)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
 }
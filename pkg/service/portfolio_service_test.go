@@ -0,0 +1,62 @@
+package service
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ericyhkim/juga/pkg/diag"
+	"github.com/ericyhkim/juga/pkg/models"
+	"github.com/ericyhkim/juga/pkg/resolver"
+	"github.com/ericyhkim/juga/pkg/storage"
+)
+
+// newTestResolver returns a resolver over empty repositories in a temporary
+// directory, with the given aliases.
+func newTestResolver(t *testing.T, aliases map[string]string) (*resolver.Resolver, *storage.PortfolioRepository) {
+	t.Helper()
+	dir := t.TempDir()
+	logger := diag.NewNopLogger()
+
+	aliasRepo := storage.NewAliasRepository(filepath.Join(dir, "aliases.json"), logger)
+	for nick, code := range aliases {
+		if err := aliasRepo.Add(nick, code); err != nil {
+			t.Fatal(err)
+		}
+	}
+	portfolios := storage.NewPortfolioRepository(filepath.Join(dir, "portfolios.json"), logger)
+
+	res := resolver.NewResolver(
+		portfolios,
+		aliasRepo,
+		storage.NewCacheRepository(filepath.Join(dir, "cache.json"), 10, logger),
+		storage.NewTickerRepository(filepath.Join(dir, "master_tickers.csv"), logger),
+		logger,
+	)
+	return res, portfolios
+}
+
+func TestBuildPositions_MergesItemsOfTheSameCode(t *testing.T) {
+	res, _ := newTestResolver(t, map[string]string{"삼전": "005930", "sam": "005930"})
+
+	holdings := []models.Holding{
+		{Item: "삼전", Quantity: 10, AvgPrice: 70000},
+		{Item: "sam", Quantity: 30, AvgPrice: 80000},
+		{Item: "000660"},
+	}
+	results := res.ResolveAll([]string{"삼전", "sam", "000660"})
+	if len(results) != 2 {
+		t.Fatalf("Expected ResolveAll to drop the second alias of 005930, got %+v", results)
+	}
+	stocks := []models.Stock{{Code: "005930", Price: 75000}, {Code: "000660", Price: 200000}}
+
+	positions := BuildPositions(holdings, results, stocks, res)
+	if len(positions) != 2 {
+		t.Fatalf("Expected 2 positions, got %+v", positions)
+	}
+	if pos := positions[0]; pos.Quantity != 40 || pos.AvgPrice != 77500 {
+		t.Errorf("Expected 40 shares @ 77500, got %g @ %g", pos.Quantity, pos.AvgPrice)
+	}
+	if pos := positions[1]; pos.HasPosition() {
+		t.Errorf("Expected a watch-only entry for 000660, got %+v", pos)
+	}
+}
@@ -1,12 +1,17 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/ericyhkim/juga/pkg/daum"
 	"github.com/ericyhkim/juga/pkg/diag"
 	"github.com/ericyhkim/juga/pkg/models"
 	"github.com/ericyhkim/juga/pkg/naver"
+	"github.com/ericyhkim/juga/pkg/quote"
+	"github.com/ericyhkim/juga/pkg/replay"
 	"github.com/ericyhkim/juga/pkg/resolver"
 	"github.com/ericyhkim/juga/pkg/search"
 )
@@ -17,37 +22,42 @@ type TickerRepository interface {
 	Count() int
 }
 
-type NaverClient interface {
-	FetchStocks(codes []string) ([]models.Stock, error)
-	FetchIndices() ([]models.Stock, error)
+// QuoteProvider is a source of quotes, index levels and price history, such as
+// naver.Client or a quote.Failover over several of them.
+type QuoteProvider interface {
+	Name() string
+	Capabilities() quote.Capability
+	FetchStocks(ctx context.Context, codes []string) ([]models.Stock, error)
+	FetchIndices(ctx context.Context) ([]models.Stock, error)
+	FetchCandles(ctx context.Context, code string, interval models.CandleInterval, from, to time.Time) ([]models.Candle, error)
 }
 
 type StockService struct {
 	tickerRepo     TickerRepository
-	client         NaverClient
+	quotes         QuoteProvider
 	logger         diag.Logger
 	scraperTimeout time.Duration
-	maxStocks      int
+	scraperOpts    []naver.ScraperOption
 }
 
 func NewStockService(
 	tickerRepo TickerRepository,
-	client NaverClient,
+	quotes QuoteProvider,
 	logger diag.Logger,
 	scraperTimeout time.Duration,
-	maxStocks int,
+	scraperOpts ...naver.ScraperOption,
 ) *StockService {
 	return &StockService{
 		tickerRepo:     tickerRepo,
-		client:         client,
+		quotes:         quotes,
 		logger:         logger,
 		scraperTimeout: scraperTimeout,
-		maxStocks:      maxStocks,
+		scraperOpts:    scraperOpts,
 	}
 }
 
 func (s *StockService) UpdateTickerDatabase() (*TickerUpdateResult, error) {
-	scraper := naver.NewScraper(s.scraperTimeout, s.logger)
+	scraper := naver.NewScraper(s.scraperTimeout, s.logger, s.scraperOpts...)
 	tickers, err := scraper.ScrapeAll()
 	if err != nil {
 		return nil, fmt.Errorf("failed to scrape tickers: %w", err)
@@ -62,19 +72,12 @@ func (s *StockService) UpdateTickerDatabase() (*TickerUpdateResult, error) {
 	}, nil
 }
 
-func (s *StockService) FetchStocks(results []resolver.ResolutionResult) (*StockFetchResult, error) {
-	isTruncated := false
-	ignoredCount := 0
-	
-	toFetch := results
-	if len(results) > s.maxStocks {
-		isTruncated = true
-		ignoredCount = len(results) - s.maxStocks
-		toFetch = results[:s.maxStocks]
-	}
-
+// FetchStocks fetches quotes for the resolved results, in the order of the results.
+// A positive limit caps the number of stocks returned; the rest are reported in
+// IgnoredCount.
+func (s *StockService) FetchStocks(ctx context.Context, results []resolver.ResolutionResult, limit int) (*StockFetchResult, error) {
 	var targetCodes []string
-	for _, res := range toFetch {
+	for _, res := range results {
 		if res.Status == resolver.StatusSuccess {
 			targetCodes = append(targetCodes, res.Code)
 		}
@@ -82,26 +85,42 @@ func (s *StockService) FetchStocks(results []resolver.ResolutionResult) (*StockF
 
 	if len(targetCodes) == 0 {
 		return &StockFetchResult{
-			Stocks:       []models.Stock{},
-			IsTruncated:  isTruncated,
-			IgnoredCount: ignoredCount,
+			Stocks: []models.Stock{},
 		}, nil
 	}
 
-	stocks, err := s.client.FetchStocks(targetCodes)
+	stocks, err := s.quotes.FetchStocks(ctx, targetCodes)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch stock data: %w", err)
+		return nil, describeFetchError("stock data", err)
 	}
 
-	return &StockFetchResult{
-		Stocks:       stocks,
-		IsTruncated:  isTruncated,
-		IgnoredCount: ignoredCount,
-	}, nil
+	result := &StockFetchResult{Stocks: stocks}
+	if limit > 0 && len(stocks) > limit {
+		result.Stocks = stocks[:limit]
+		result.IsTruncated = true
+		result.IgnoredCount = len(stocks) - limit
+	}
+	return result, nil
 }
 
-func (s *StockService) FetchIndices() ([]models.Stock, error) {
-	return s.client.FetchIndices()
+func (s *StockService) FetchIndices(ctx context.Context) ([]models.Stock, error) {
+	indices, err := s.quotes.FetchIndices(ctx)
+	if err != nil {
+		return nil, describeFetchError("market indices", err)
+	}
+	return indices, nil
+}
+
+func (s *StockService) FetchCandles(ctx context.Context, code string, interval models.CandleInterval, from, to time.Time) ([]models.Candle, error) {
+	if to.Before(from) {
+		return nil, fmt.Errorf("invalid range: %s is after %s", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	}
+
+	candles, err := s.quotes.FetchCandles(ctx, code, interval, from, to)
+	if err != nil {
+		return nil, describeFetchError("price history", err)
+	}
+	return candles, nil
 }
 
 func (s *StockService) SearchTickers(query string) ([]models.Ticker, error) {
@@ -113,3 +132,42 @@ func (s *StockService) SearchTickers(query string) ([]models.Ticker, error) {
 
 	return search.FindTickers(s.tickerRepo.(interface{ GetAll() []models.Ticker }).GetAll(), query), nil
 }
+
+// describeFetchError explains a failed quote request in terms of what the user can
+// do about it. The original error stays available to errors.Is and errors.As;
+// cancellations are returned unchanged.
+func describeFetchError(what string, err error) error {
+	var netErr *quote.NetworkError
+	var statusErr *quote.StatusError
+	var decodeErr *quote.DecodeError
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return err
+	case errors.Is(err, replay.ErrNotRecorded):
+		return fmt.Errorf("failed to fetch %s: the request is missing from the replayed recording: %w", what, err)
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return fmt.Errorf("failed to fetch %s: %s did not respond in time: %w", what, providerTitle(netErr.Provider), err)
+	case errors.As(err, &netErr):
+		return fmt.Errorf("failed to fetch %s: could not reach %s, check your connection: %w", what, providerTitle(netErr.Provider), err)
+	case errors.As(err, &statusErr) && statusErr.Temporary():
+		return fmt.Errorf("failed to fetch %s: %s is having trouble, try again later: %w", what, providerTitle(statusErr.Provider), err)
+	case errors.As(err, &decodeErr):
+		return fmt.Errorf("failed to fetch %s: unexpected response from %s, the API may have changed: %w", what, providerTitle(decodeErr.Provider), err)
+	case errors.Is(err, quote.ErrUnsupported):
+		return fmt.Errorf("failed to fetch %s: no configured quote provider supports it: %w", what, err)
+	default:
+		return fmt.Errorf("failed to fetch %s: %w", what, err)
+	}
+}
+
+func providerTitle(name string) string {
+	switch name {
+	case naver.ProviderName:
+		return "Naver Finance"
+	case daum.ProviderName:
+		return "Daum Finance"
+	default:
+		return "the quote provider"
+	}
+}
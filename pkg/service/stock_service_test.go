@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ericyhkim/juga/pkg/diag"
+	"github.com/ericyhkim/juga/pkg/quote"
+	"github.com/ericyhkim/juga/pkg/quote/quotetest"
+	"github.com/ericyhkim/juga/pkg/resolver"
+)
+
+func resolvedCodes(n int) []resolver.ResolutionResult {
+	results := make([]resolver.ResolutionResult, 0, n+1)
+	for i := range n {
+		results = append(results, resolver.ResolutionResult{
+			Code:   fmt.Sprintf("%06d", i),
+			Status: resolver.StatusSuccess,
+		})
+	}
+	return append(results, resolver.ResolutionResult{Input: "???", Status: resolver.StatusNotFound})
+}
+
+func TestStockService_FetchStocksLimit(t *testing.T) {
+	client := &quotetest.Provider{}
+	svc := NewStockService(nil, client, diag.NewNopLogger(), time.Second)
+	results := resolvedCodes(60)
+
+	res, err := svc.FetchStocks(context.Background(), results, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(client.Requested()) != 60 || len(res.Stocks) != 60 || res.IsTruncated {
+		t.Errorf("Expected all 60 stocks without a limit, got %d (requested %d, truncated %v)",
+			len(res.Stocks), len(client.Requested()), res.IsTruncated)
+	}
+
+	res, err = svc.FetchStocks(context.Background(), results, 25)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(res.Stocks) != 25 || !res.IsTruncated || res.IgnoredCount != 35 {
+		t.Errorf("Expected 25 stocks and 35 ignored, got %d and %d", len(res.Stocks), res.IgnoredCount)
+	}
+	for i, s := range res.Stocks {
+		if s.Code != results[i].Code {
+			t.Fatalf("Expected stock %d to be %s, got %s", i, results[i].Code, s.Code)
+		}
+	}
+}
+
+func TestStockService_FetchErrors(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{&quote.StatusError{Provider: "naver", StatusCode: 503}, "failed to fetch stock data: Naver Finance is having trouble, try again later: naver api returned status: 503 Service Unavailable"},
+		{context.Canceled, "context canceled"},
+	}
+
+	for _, tt := range tests {
+		client := &quotetest.Provider{}
+		client.Fail(tt.err)
+		svc := NewStockService(nil, client, diag.NewNopLogger(), time.Second)
+		_, err := svc.FetchStocks(context.Background(), resolvedCodes(1), 0)
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("Expected %q, got %v", tt.want, err)
+		}
+		if !errors.Is(err, tt.err) {
+			t.Errorf("Expected %v to wrap %v", err, tt.err)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ericyhkim/juga/pkg/diag"
+	"github.com/ericyhkim/juga/pkg/models"
+)
+
+const alertSchemaVersion = 1
+
+type alertFile struct {
+	Version int            `json:"version"`
+	NextID  int            `json:"next_id"`
+	Alerts  []models.Alert `json:"alerts"`
+}
+
+type AlertRepository struct {
+	filePath string
+	data     alertFile
+	logger   diag.Logger
+}
+
+func NewAlertRepository(filePath string, logger diag.Logger) *AlertRepository {
+	return &AlertRepository{
+		filePath: filePath,
+		data:     alertFile{Version: alertSchemaVersion, NextID: 1},
+		logger:   logger,
+	}
+}
+
+func (r *AlertRepository) Load() error {
+	data, err := os.ReadFile(r.filePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read alerts file: %w", err)
+	}
+
+	if len(data) == 0 {
+		return nil
+	}
+
+	var file alertFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse alerts JSON: %w", err)
+	}
+	if file.Version > alertSchemaVersion {
+		return fmt.Errorf("alerts file version %d is newer than supported version %d", file.Version, alertSchemaVersion)
+	}
+
+	for _, a := range file.Alerts {
+		if a.ID >= file.NextID {
+			file.NextID = a.ID + 1
+		}
+	}
+	file.Version = alertSchemaVersion
+	r.data = file
+
+	return nil
+}
+
+func (r *AlertRepository) Save() error {
+	data, err := json.MarshalIndent(r.data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal alerts: %w", err)
+	}
+
+	if err := os.WriteFile(r.filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write alerts file: %w", err)
+	}
+	return nil
+}
+
+// Add stores a new alert and returns it with its assigned ID.
+func (r *AlertRepository) Add(alert models.Alert) (models.Alert, error) {
+	alert.ID = r.data.NextID
+	r.data.NextID++
+	r.data.Alerts = append(r.data.Alerts, alert)
+	return alert, r.Save()
+}
+
+// Remove deletes the alert with the given ID and reports whether it existed.
+func (r *AlertRepository) Remove(id int) (bool, error) {
+	for i, a := range r.data.Alerts {
+		if a.ID == id {
+			r.data.Alerts = append(r.data.Alerts[:i], r.data.Alerts[i+1:]...)
+			return true, r.Save()
+		}
+	}
+	return false, nil
+}
+
+func (r *AlertRepository) GetAll() []models.Alert {
+	alerts := make([]models.Alert, len(r.data.Alerts))
+	copy(alerts, r.data.Alerts)
+	return alerts
+}
+
+// SaveState stores the trigger state of the given alerts. The file is read again first
+// and only the state of the alerts still in it is updated, so alerts added or removed
+// by another process since the last load are kept.
+func (r *AlertRepository) SaveState(alerts []models.Alert) error {
+	if err := r.Load(); err != nil {
+		return err
+	}
+
+	byID := make(map[int]models.Alert, len(alerts))
+	for _, a := range alerts {
+		byID[a.ID] = a
+	}
+	for i, a := range r.data.Alerts {
+		if state, ok := byID[a.ID]; ok {
+			r.data.Alerts[i].Triggered = state.Triggered
+			r.data.Alerts[i].LastFired = state.LastFired
+		}
+	}
+	return r.Save()
+}
@@ -0,0 +1,40 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ericyhkim/juga/pkg/diag"
+	"github.com/ericyhkim/juga/pkg/models"
+)
+
+func TestAlertRepository_IDsSurviveReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "alerts.json")
+
+	repo := NewAlertRepository(path, diag.NewNopLogger())
+	first, err := repo.Add(models.Alert{Code: "005930", Condition: models.AlertAbove, Threshold: 80000})
+	if err != nil {
+		t.Fatalf("Add() returned error: %v", err)
+	}
+	second, _ := repo.Add(models.Alert{Code: "000660", Condition: models.AlertBelow, Threshold: 100000})
+	if first.ID != 1 || second.ID != 2 {
+		t.Fatalf("Expected IDs 1 and 2, got %d and %d", first.ID, second.ID)
+	}
+
+	if ok, err := repo.Remove(second.ID); !ok || err != nil {
+		t.Fatalf("Remove() = %v, %v", ok, err)
+	}
+
+	reloaded := NewAlertRepository(path, diag.NewNopLogger())
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if got := reloaded.GetAll(); len(got) != 1 || got[0].Code != "005930" {
+		t.Fatalf("Expected one alert after reload, got %+v", got)
+	}
+
+	third, _ := reloaded.Add(models.Alert{Code: "035420", Condition: models.AlertChangePct, Threshold: 5})
+	if third.ID != 3 {
+		t.Errorf("Expected removed IDs not to be reused, got %d", third.ID)
+	}
+}
@@ -2,51 +2,109 @@ package storage
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
+	"slices"
+	"time"
 
 	"github.com/ericyhkim/juga/pkg/diag"
+	"github.com/ericyhkim/juga/pkg/models"
 )
 
+// cacheSchemaVersion is the current on-disk format of cache.json.
+// Version 1 (unversioned) mapped terms to codes in "data" without timestamps.
+const cacheSchemaVersion = 2
+
+type cacheFile struct {
+	Version int `json:"version"`
+	// TickerVersion identifies the ticker database the entries were resolved against.
+	TickerVersion string                `json:"ticker_version,omitempty"`
+	TTLSeconds    int64                 `json:"ttl_seconds,omitempty"`
+	Entries       map[string]cacheEntry `json:"entries"`
+	// Order lists the terms from most to least recently used.
+	Order []string `json:"order"`
+
+	// Data holds the entries of version 1 files and is only read for migration.
+	Data map[string]string `json:"data,omitempty"`
+}
+
+type cacheEntry struct {
+	Code      string    `json:"code"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
 type CacheRepository struct {
 	filePath string
-	Data     map[string]string `json:"data"`
-	Order    []string          `json:"order"`
+	data     cacheFile
 	limit    int
 	dirty    bool
 	logger   diag.Logger
+	now      func() time.Time
 }
 
 func NewCacheRepository(filePath string, limit int, logger diag.Logger) *CacheRepository {
 	return &CacheRepository{
 		filePath: filePath,
-		Data:     make(map[string]string),
-		Order:    make([]string, 0),
+		data:     newCacheFile(),
 		limit:    limit,
 		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+func newCacheFile() cacheFile {
+	return cacheFile{
+		Version: cacheSchemaVersion,
+		Entries: make(map[string]cacheEntry),
+		Order:   make([]string, 0),
 	}
 }
 
 func (r *CacheRepository) Load() error {
-	f, err := os.Open(r.filePath)
+	data, err := os.ReadFile(r.filePath)
 	if os.IsNotExist(err) {
 		return nil
 	}
 	if err != nil {
 		return err
 	}
-	defer f.Close()
 
-	if err := json.NewDecoder(f).Decode(r); err != nil {
+	var file cacheFile
+	if err := json.Unmarshal(data, &file); err != nil {
 		return err
 	}
+	if file.Version > cacheSchemaVersion {
+		return fmt.Errorf("cache file version %d is newer than supported version %d", file.Version, cacheSchemaVersion)
+	}
 
-	if r.Data == nil {
-		r.Data = make(map[string]string)
+	if file.Entries == nil {
+		file.Entries = make(map[string]cacheEntry)
 	}
-	if r.Order == nil {
-		r.Order = make([]string, 0)
+	if file.Order == nil {
+		file.Order = make([]string, 0)
 	}
 
+	// Legacy entries have no timestamp; they count as resolved now so that a TTL
+	// does not expire all of them at once.
+	if file.Version < cacheSchemaVersion {
+		now := r.now()
+		for term, code := range file.Data {
+			file.Entries[term] = cacheEntry{Code: code, UpdatedAt: now}
+		}
+		file.Data = nil
+		file.Version = cacheSchemaVersion
+
+		r.data = file
+		r.dirty = true
+		if err := r.Save(); err != nil {
+			r.logger.Warn("Warning: failed to save migrated cache: %v", err)
+			return nil
+		}
+		r.logger.Debug("Migrated %d cache entries to schema version %d", len(file.Entries), cacheSchemaVersion)
+		return nil
+	}
+
+	r.data = file
 	return nil
 }
 
@@ -55,15 +113,11 @@ func (r *CacheRepository) Save() error {
 		return nil
 	}
 
-	f, err := os.Create(r.filePath)
+	data, err := json.MarshalIndent(r.data, "", "  ")
 	if err != nil {
 		return err
 	}
-	defer f.Close()
-
-	encoder := json.NewEncoder(f)
-	encoder.SetIndent("", "  ")
-	if err := encoder.Encode(r); err != nil {
+	if err := os.WriteFile(r.filePath, data, 0644); err != nil {
 		return err
 	}
 
@@ -71,49 +125,103 @@ func (r *CacheRepository) Save() error {
 	return nil
 }
 
+// Get returns the code cached for term. Entries older than the TTL are dropped.
 func (r *CacheRepository) Get(term string) (string, bool) {
-	code, ok := r.Data[term]
-	if ok {
-		r.moveToFront(term)
+	entry, ok := r.data.Entries[term]
+	if !ok {
+		return "", false
+	}
+
+	if ttl := r.TTL(); ttl > 0 && r.now().Sub(entry.UpdatedAt) > ttl {
+		r.Remove(term)
+		return "", false
 	}
-	return code, ok
+
+	r.moveToFront(term)
+	return entry.Code, true
 }
 
 func (r *CacheRepository) Set(term, code string) {
-	if oldCode, ok := r.Data[term]; ok && oldCode == code {
-		r.moveToFront(term)
-		return
+	r.data.Entries[term] = cacheEntry{Code: code, UpdatedAt: r.now()}
+	r.moveToFront(term)
+	r.dirty = true
+
+	if len(r.data.Order) > r.limit {
+		toRemove := r.data.Order[len(r.data.Order)-1]
+		delete(r.data.Entries, toRemove)
+		r.data.Order = r.data.Order[:len(r.data.Order)-1]
 	}
+}
 
-	r.Data[term] = code
-	r.moveToFront(term)
+// Remove deletes the entry for term and reports whether it existed.
+func (r *CacheRepository) Remove(term string) bool {
+	if _, ok := r.data.Entries[term]; !ok {
+		return false
+	}
+
+	delete(r.data.Entries, term)
+	r.data.Order = slices.DeleteFunc(r.data.Order, func(t string) bool { return t == term })
 	r.dirty = true
+	return true
+}
 
-	if len(r.Order) > r.limit {
-		toRemove := r.Order[len(r.Order)-1]
-		delete(r.Data, toRemove)
-		r.Order = r.Order[:len(r.Order)-1]
+// Entries returns the cached terms from most to least recently used.
+func (r *CacheRepository) Entries() []models.CacheEntry {
+	entries := make([]models.CacheEntry, 0, len(r.data.Order))
+	for _, term := range r.data.Order {
+		if e, ok := r.data.Entries[term]; ok {
+			entries = append(entries, models.CacheEntry{Term: term, Code: e.Code, UpdatedAt: e.UpdatedAt})
+		}
 	}
+	return entries
 }
 
 func (r *CacheRepository) Clear() {
-	r.Data = make(map[string]string)
-	r.Order = make([]string, 0)
+	r.data.Entries = make(map[string]cacheEntry)
+	r.data.Order = make([]string, 0)
 	r.dirty = true
 }
 
-func (r *CacheRepository) moveToFront(term string) {
-	idx := -1
-	for i, t := range r.Order {
-		if t == term {
-			idx = i
-			break
+// TTL is how long entries stay valid, or 0 when they never expire.
+func (r *CacheRepository) TTL() time.Duration {
+	return time.Duration(r.data.TTLSeconds) * time.Second
+}
+
+func (r *CacheRepository) SetTTL(ttl time.Duration) {
+	r.data.TTLSeconds = int64(ttl / time.Second)
+	r.dirty = true
+}
+
+func (r *CacheRepository) TickerVersion() string {
+	return r.data.TickerVersion
+}
+
+// Revalidate drops the entries rejected by keep, records the ticker database
+// version they were checked against and returns the number of entries dropped.
+func (r *CacheRepository) Revalidate(version string, keep func(term, code string) bool) int {
+	removed := 0
+	for _, term := range slices.Clone(r.data.Order) {
+		if !keep(term, r.data.Entries[term].Code) {
+			r.Remove(term)
+			removed++
 		}
 	}
 
+	if r.data.TickerVersion != version {
+		r.data.TickerVersion = version
+		r.dirty = true
+	}
+	return removed
+}
+
+func (r *CacheRepository) moveToFront(term string) {
+	idx := slices.Index(r.data.Order, term)
+	if idx == 0 {
+		return
+	}
 	if idx != -1 {
-		r.Order = append(r.Order[:idx], r.Order[idx+1:]...)
+		r.data.Order = slices.Delete(r.data.Order, idx, idx+1)
 	}
 
-	r.Order = append([]string{term}, r.Order...)
+	r.data.Order = slices.Insert(r.data.Order, 0, term)
 }
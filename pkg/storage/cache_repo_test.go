@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ericyhkim/juga/pkg/diag"
+)
+
+func TestCacheRepository_MigratesLegacyFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.json")
+	legacy := `{"data": {"삼전": "005930", "하이닉스": "000660"}, "order": ["하이닉스", "삼전"]}`
+	if err := os.WriteFile(path, []byte(legacy), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	repo := NewCacheRepository(path, 10, diag.NewNopLogger())
+	if err := repo.Load(); err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	entries := repo.Entries()
+	if len(entries) != 2 || entries[0].Term != "하이닉스" || entries[1].Code != "005930" {
+		t.Fatalf("Expected legacy entries in LRU order, got %+v", entries)
+	}
+	if entries[0].UpdatedAt.IsZero() {
+		t.Errorf("Expected migrated entries to be timestamped")
+	}
+
+	reloaded := NewCacheRepository(path, 10, diag.NewNopLogger())
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("Load() of migrated file returned error: %v", err)
+	}
+	if code, ok := reloaded.Get("삼전"); !ok || code != "005930" {
+		t.Errorf("Expected the migrated file to be saved, got %q (found=%v)", code, ok)
+	}
+}
+
+func TestCacheRepository_TTL(t *testing.T) {
+	repo := NewCacheRepository(filepath.Join(t.TempDir(), "cache.json"), 10, diag.NewNopLogger())
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	repo.now = func() time.Time { return now }
+
+	repo.Set("삼전", "005930")
+	repo.SetTTL(time.Hour)
+
+	now = now.Add(30 * time.Minute)
+	if _, ok := repo.Get("삼전"); !ok {
+		t.Fatal("Expected the entry to be valid within the TTL")
+	}
+
+	now = now.Add(time.Hour)
+	if _, ok := repo.Get("삼전"); ok {
+		t.Error("Expected the entry to expire after the TTL")
+	}
+	if len(repo.Entries()) != 0 {
+		t.Errorf("Expected the expired entry to be dropped, got %+v", repo.Entries())
+	}
+}
+
+func TestCacheRepository_RemoveAndRevalidate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.json")
+	repo := NewCacheRepository(path, 10, diag.NewNopLogger())
+	repo.Set("삼전", "005930")
+	repo.Set("하이닉스", "000660")
+	repo.Set("카카오", "035720")
+
+	if !repo.Remove("하이닉스") || repo.Remove("하이닉스") {
+		t.Error("Expected Remove to report whether the entry existed")
+	}
+
+	removed := repo.Revalidate("v2", func(term, code string) bool { return code != "035720" })
+	if removed != 1 || repo.TickerVersion() != "v2" {
+		t.Errorf("Expected 1 entry dropped at version v2, got %d at %q", removed, repo.TickerVersion())
+	}
+
+	if err := repo.Save(); err != nil {
+		t.Fatal(err)
+	}
+	reloaded := NewCacheRepository(path, 10, diag.NewNopLogger())
+	if err := reloaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	entries := reloaded.Entries()
+	if len(entries) != 1 || entries[0].Term != "삼전" || reloaded.TickerVersion() != "v2" {
+		t.Errorf("Expected only 삼전 at version v2 after reload, got %+v (%q)", entries, reloaded.TickerVersion())
+	}
+}
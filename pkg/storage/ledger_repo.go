@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ericyhkim/juga/pkg/diag"
+	"github.com/ericyhkim/juga/pkg/models"
+)
+
+const ledgerSchemaVersion = 1
+
+type ledgerFile struct {
+	Version      int                              `json:"version"`
+	Settings     map[string]models.LedgerSettings `json:"settings"`
+	Transactions map[string][]models.Transaction  `json:"transactions"`
+}
+
+// LedgerRepository persists per-portfolio transaction logs. Transactions can only be
+// appended; positions are always derived from the full log.
+type LedgerRepository struct {
+	filePath string
+	data     ledgerFile
+	defaults models.LedgerSettings
+	logger   diag.Logger
+}
+
+func NewLedgerRepository(filePath string, defaults models.LedgerSettings, logger diag.Logger) *LedgerRepository {
+	return &LedgerRepository{
+		filePath: filePath,
+		data: ledgerFile{
+			Version:      ledgerSchemaVersion,
+			Settings:     make(map[string]models.LedgerSettings),
+			Transactions: make(map[string][]models.Transaction),
+		},
+		defaults: defaults,
+		logger:   logger,
+	}
+}
+
+func (r *LedgerRepository) Load() error {
+	data, err := os.ReadFile(r.filePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read ledger file: %w", err)
+	}
+
+	if len(data) == 0 {
+		return nil
+	}
+
+	var file ledgerFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse ledger JSON: %w", err)
+	}
+	if file.Version > ledgerSchemaVersion {
+		return fmt.Errorf("ledger file version %d is newer than supported version %d", file.Version, ledgerSchemaVersion)
+	}
+
+	if file.Settings == nil {
+		file.Settings = make(map[string]models.LedgerSettings)
+	}
+	if file.Transactions == nil {
+		file.Transactions = make(map[string][]models.Transaction)
+	}
+	file.Version = ledgerSchemaVersion
+	r.data = file
+
+	return nil
+}
+
+func (r *LedgerRepository) Save() error {
+	data, err := json.MarshalIndent(r.data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal ledger: %w", err)
+	}
+
+	if err := os.WriteFile(r.filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write ledger file: %w", err)
+	}
+	return nil
+}
+
+func (r *LedgerRepository) Append(portfolio string, tx models.Transaction) error {
+	r.data.Transactions[portfolio] = append(r.data.Transactions[portfolio], tx)
+	return r.Save()
+}
+
+func (r *LedgerRepository) Transactions(portfolio string) []models.Transaction {
+	txs := r.data.Transactions[portfolio]
+	txsCopy := make([]models.Transaction, len(txs))
+	copy(txsCopy, txs)
+	return txsCopy
+}
+
+// Settings returns the trading costs of a portfolio, falling back to the defaults.
+func (r *LedgerRepository) Settings(portfolio string) models.LedgerSettings {
+	if s, ok := r.data.Settings[portfolio]; ok {
+		return s
+	}
+	return r.defaults
+}
+
+func (r *LedgerRepository) SetSettings(portfolio string, settings models.LedgerSettings) error {
+	r.data.Settings[portfolio] = settings
+	return r.Save()
+}
@@ -6,18 +6,28 @@ import (
 	"os"
 
 	"github.com/ericyhkim/juga/pkg/diag"
+	"github.com/ericyhkim/juga/pkg/models"
 )
 
+// portfolioSchemaVersion is the current on-disk format of portfolios.json.
+// Version 1 (unversioned) stored each portfolio as a plain list of inputs.
+const portfolioSchemaVersion = 2
+
+type portfolioFile struct {
+	Version    int                         `json:"version"`
+	Portfolios map[string][]models.Holding `json:"portfolios"`
+}
+
 type PortfolioRepository struct {
 	filePath   string
-	portfolios map[string][]string
+	portfolios map[string][]models.Holding
 	logger     diag.Logger
 }
 
 func NewPortfolioRepository(filePath string, logger diag.Logger) *PortfolioRepository {
 	return &PortfolioRepository{
 		filePath:   filePath,
-		portfolios: make(map[string][]string),
+		portfolios: make(map[string][]models.Holding),
 		logger:     logger,
 	}
 }
@@ -35,15 +45,36 @@ func (r *PortfolioRepository) Load() error {
 		return nil
 	}
 
-	if err := json.Unmarshal(data, &r.portfolios); err != nil {
+	var file portfolioFile
+	if err := json.Unmarshal(data, &file); err == nil && file.Version > 0 {
+		if file.Version > portfolioSchemaVersion {
+			return fmt.Errorf("portfolios file version %d is newer than supported version %d", file.Version, portfolioSchemaVersion)
+		}
+		if file.Portfolios != nil {
+			r.portfolios = file.Portfolios
+		}
+		return nil
+	}
+
+	var legacy map[string][]string
+	if err := json.Unmarshal(data, &legacy); err != nil {
 		return fmt.Errorf("failed to parse portfolios JSON: %w", err)
 	}
 
+	r.portfolios = migrateLegacyPortfolios(legacy)
+	if err := r.Save(); err != nil {
+		r.logger.Warn("Warning: failed to save migrated portfolios: %v", err)
+		return nil
+	}
+	r.logger.Debug("Migrated %d portfolios to schema version %d", len(r.portfolios), portfolioSchemaVersion)
 	return nil
 }
 
 func (r *PortfolioRepository) Save() error {
-	data, err := json.MarshalIndent(r.portfolios, "", "  ")
+	data, err := json.MarshalIndent(portfolioFile{
+		Version:    portfolioSchemaVersion,
+		Portfolios: r.portfolios,
+	}, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal portfolios: %w", err)
 	}
@@ -54,8 +85,8 @@ func (r *PortfolioRepository) Save() error {
 	return nil
 }
 
-func (r *PortfolioRepository) Add(name string, items []string) error {
-	r.portfolios[name] = items
+func (r *PortfolioRepository) Add(name string, holdings []models.Holding) error {
+	r.portfolios[name] = holdings
 	return r.Save()
 }
 
@@ -64,19 +95,49 @@ func (r *PortfolioRepository) Remove(name string) error {
 	return r.Save()
 }
 
+// Get returns the raw inputs of a portfolio, ready for resolution.
 func (r *PortfolioRepository) Get(name string) ([]string, bool) {
-	items, ok := r.portfolios[name]
-	return items, ok
+	holdings, ok := r.portfolios[name]
+	if !ok {
+		return nil, false
+	}
+
+	items := make([]string, len(holdings))
+	for i, h := range holdings {
+		items[i] = h.Item
+	}
+	return items, true
+}
+
+func (r *PortfolioRepository) GetHoldings(name string) ([]models.Holding, bool) {
+	holdings, ok := r.portfolios[name]
+	if !ok {
+		return nil, false
+	}
+
+	holdingsCopy := make([]models.Holding, len(holdings))
+	copy(holdingsCopy, holdings)
+	return holdingsCopy, true
 }
 
-func (r *PortfolioRepository) GetAll() map[string][]string {
-	copy := make(map[string][]string, len(r.portfolios))
+func (r *PortfolioRepository) GetAll() map[string][]models.Holding {
+	all := make(map[string][]models.Holding, len(r.portfolios))
 	for k, v := range r.portfolios {
-		itemsCopy := make([]string, len(v))
-		for i, item := range v {
-			itemsCopy[i] = item
+		holdingsCopy := make([]models.Holding, len(v))
+		copy(holdingsCopy, v)
+		all[k] = holdingsCopy
+	}
+	return all
+}
+
+func migrateLegacyPortfolios(legacy map[string][]string) map[string][]models.Holding {
+	migrated := make(map[string][]models.Holding, len(legacy))
+	for name, items := range legacy {
+		holdings := make([]models.Holding, 0, len(items))
+		for _, item := range items {
+			holdings = append(holdings, models.Holding{Item: item})
 		}
-		copy[k] = itemsCopy
+		migrated[name] = holdings
 	}
-	return copy
+	return migrated
 }
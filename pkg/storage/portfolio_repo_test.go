@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ericyhkim/juga/pkg/diag"
+	"github.com/ericyhkim/juga/pkg/models"
+)
+
+func TestPortfolioRepository_MigratesLegacyFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "portfolios.json")
+	legacy := `{"tech": ["삼전", "카카오"], "version": ["005930"]}`
+	if err := os.WriteFile(path, []byte(legacy), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	repo := NewPortfolioRepository(path, diag.NewNopLogger())
+	if err := repo.Load(); err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	items, ok := repo.Get("tech")
+	if !ok || len(items) != 2 || items[0] != "삼전" {
+		t.Errorf("Expected legacy items to be migrated, got %v", items)
+	}
+	if _, ok := repo.Get("version"); !ok {
+		t.Errorf("Expected a portfolio literally named 'version' to survive migration")
+	}
+
+	if err := repo.Save(); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), `"version": 2`) {
+		t.Errorf("Expected saved file to carry schema version, got %s", data)
+	}
+}
+
+func TestPortfolioRepository_RoundTripHoldings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "portfolios.json")
+
+	repo := NewPortfolioRepository(path, diag.NewNopLogger())
+	holdings := []models.Holding{
+		{Item: "삼전", Quantity: 10, AvgPrice: 70000},
+		{Item: "카카오"},
+	}
+	if err := repo.Add("tech", holdings); err != nil {
+		t.Fatalf("Add() returned error: %v", err)
+	}
+
+	reloaded := NewPortfolioRepository(path, diag.NewNopLogger())
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	got, ok := reloaded.GetHoldings("tech")
+	if !ok || len(got) != 2 {
+		t.Fatalf("Expected 2 holdings, got %v", got)
+	}
+	if got[0] != holdings[0] || got[1] != holdings[1] {
+		t.Errorf("Expected %v, got %v", holdings, got)
+	}
+}
@@ -2,10 +2,13 @@ package storage
 
 import (
 	"bytes"
+	"crypto/sha256"
 	_ "embed"
 	"encoding/csv"
+	"encoding/hex"
 	"fmt"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/ericyhkim/juga/pkg/diag"
@@ -18,6 +21,7 @@ var defaultTickersCSV []byte
 type TickerRepository struct {
 	filePath string
 	tickers  []models.Ticker
+	version  string
 	logger   diag.Logger
 }
 
@@ -56,45 +60,37 @@ func (r *TickerRepository) Load() error {
 		}
 	}
 
-	f, err := os.Open(r.filePath)
+	data, err := os.ReadFile(r.filePath)
 	if err != nil {
 		return fmt.Errorf("failed to open tickers file: %w", err)
 	}
-	defer f.Close()
 
-	reader := csv.NewReader(f)
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
 	records, err := reader.ReadAll()
 	if err != nil {
 		return fmt.Errorf("failed to parse tickers CSV: %w", err)
 	}
+	r.version = contentVersion(data)
 
 	var loaded []models.Ticker
 	for _, record := range records {
-		if len(record) < 3 {
-			continue
+		if t, ok := parseTickerRecord(record); ok {
+			loaded = append(loaded, t)
 		}
-		loaded = append(loaded, models.Ticker{
-			Code:   record[0],
-			Name:   record[1],
-			Market: record[2],
-		})
 	}
 
 	embeddedCount := bytes.Count(defaultTickersCSV, []byte{'\n'})
 	if len(loaded) < embeddedCount {
 		reader := csv.NewReader(bytes.NewReader(defaultTickersCSV))
+		reader.FieldsPerRecord = -1
 		embeddedRecords, err := reader.ReadAll()
 		if err == nil {
 			var embeddedLoaded []models.Ticker
 			for _, record := range embeddedRecords {
-				if len(record) < 3 {
-					continue
+				if t, ok := parseTickerRecord(record); ok {
+					embeddedLoaded = append(embeddedLoaded, t)
 				}
-				embeddedLoaded = append(embeddedLoaded, models.Ticker{
-					Code:   record[0],
-					Name:   record[1],
-					Market: record[2],
-				})
 			}
 
 			if len(embeddedLoaded) > len(loaded) {
@@ -111,25 +107,74 @@ func (r *TickerRepository) Load() error {
 }
 
 func (r *TickerRepository) Save(tickers []models.Ticker) error {
-	f, err := os.Create(r.filePath)
-	if err != nil {
-		return fmt.Errorf("failed to create tickers file: %w", err)
-	}
-	defer f.Close()
-
-	writer := csv.NewWriter(f)
-	defer writer.Flush()
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
 
 	for _, t := range tickers {
-		if err := writer.Write([]string{t.Code, t.Name, t.Market}); err != nil {
+		rank := ""
+		if t.Rank > 0 {
+			rank = strconv.Itoa(t.Rank)
+		}
+		kind := ""
+		if t.ETF {
+			kind = etfKind
+		}
+		if err := writer.Write([]string{t.Code, t.Name, t.Market, t.EnglishName, rank, kind}); err != nil {
 			return err
 		}
 	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(r.filePath, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to create tickers file: %w", err)
+	}
 
 	r.tickers = tickers
+	r.version = contentVersion(buf.Bytes())
 	return nil
 }
 
+// Version identifies the loaded ticker database by a hash of its contents, or is
+// empty before Load.
+func (r *TickerRepository) Version() string {
+	return r.version
+}
+
+func contentVersion(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// etfKind marks ETF rows in the kind column of master_tickers.csv.
+const etfKind = "etf"
+
+// parseTickerRecord reads a CSV row of code, name, market and the optional English
+// name, market-cap rank and kind columns, so older three-column files keep loading.
+func parseTickerRecord(record []string) (models.Ticker, bool) {
+	if len(record) < 3 {
+		return models.Ticker{}, false
+	}
+
+	t := models.Ticker{
+		Code:   record[0],
+		Name:   record[1],
+		Market: record[2],
+	}
+	if len(record) > 3 {
+		t.EnglishName = record[3]
+	}
+	if len(record) > 4 {
+		t.Rank, _ = strconv.Atoi(record[4])
+	}
+	if len(record) > 5 {
+		t.ETF = record[5] == etfKind
+	}
+	return t, true
+}
+
 func (r *TickerRepository) GetAll() []models.Ticker {
 	return r.tickers
 }
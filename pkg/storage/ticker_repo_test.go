@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ericyhkim/juga/pkg/diag"
+	"github.com/ericyhkim/juga/pkg/models"
+)
+
+func TestTickerRepository_OptionalColumns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "master_tickers.csv")
+	repo := NewTickerRepository(path, diag.NewNopLogger())
+
+	tickers := []models.Ticker{
+		{Code: "005930", Name: "삼성전자", Market: "KOSPI", EnglishName: "SamsungElec", Rank: 1},
+		{Code: "035720", Name: "카카오", Market: "KOSPI"},
+		{Code: "069500", Name: "KODEX 200", Market: "KOSPI", ETF: true},
+	}
+	if err := repo.Save(tickers); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+
+	record, ok := parseTickerRecord([]string{"005930", "삼성전자", "KOSPI", "SamsungElec", "1"})
+	if !ok || record != tickers[0] {
+		t.Errorf("parseTickerRecord() = %+v, want %+v", record, tickers[0])
+	}
+
+	legacy, ok := parseTickerRecord([]string{"035720", "카카오", "KOSPI"})
+	if !ok || legacy != tickers[1] {
+		t.Errorf("Expected three-column rows to keep loading, got %+v", legacy)
+	}
+
+	etf, ok := parseTickerRecord([]string{"069500", "KODEX 200", "KOSPI", "", "", "etf"})
+	if !ok || etf != tickers[2] {
+		t.Errorf("Expected ETF kind to be read, got %+v", etf)
+	}
+
+	data, _ := os.ReadFile(path)
+	if want := "005930,삼성전자,KOSPI,SamsungElec,1,\n035720,카카오,KOSPI,,,\n069500,KODEX 200,KOSPI,,,etf\n"; string(data) != want {
+		t.Errorf("Unexpected CSV:\n%s", data)
+	}
+}